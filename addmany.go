@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// parseTimerSpecs turns "Name|duration" specs into running timers with the
+// given tags. Blank specs and "#" comments are ignored. Specs that can't be
+// used are reported in failed, so one typo doesn't lose the rest.
+func parseTimerSpecs(specs []string, tags []string, now time.Time) (timers []Timer, failed []string) {
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" || strings.HasPrefix(spec, "#") {
+			continue
+		}
+		// The duration comes after the last "|", so names may contain one
+		i := strings.LastIndex(spec, "|")
+		if i < 0 {
+			failed = append(failed, fmt.Sprintf("%q: expected Name|duration", spec))
+			continue
+		}
+		name, durationStr := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+		if name == "" {
+			failed = append(failed, fmt.Sprintf("%q: missing name", spec))
+			continue
+		}
+		d, err := parseDuration(durationStr)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%q: invalid duration: %v", spec, err))
+			continue
+		}
+		timers = append(timers, Timer{
+			ID:       newTimerID(),
+			Name:     name,
+			End:      now.Add(d),
+			Duration: d,
+			Created:  now,
+			Started:  now,
+			Tags:     slices.Clone(tags),
+		})
+	}
+	return timers, failed
+}
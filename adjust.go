@@ -24,3 +24,30 @@ func adjustDuration(currentInput string, delta time.Duration, config DurationAdj
 	// Format back to string
 	return formatForInput(newDur)
 }
+
+// remainingStep returns one adjustment step for a timer with this much time
+// left, sized like the form's +/- step for a duration of that magnitude
+func remainingStep(remaining time.Duration, config DurationAdjustConfig) time.Duration {
+	return time.Duration(config.IncrementStep) * getUnitMultiplier(config.Unit, formatForInput(remaining))
+}
+
+// adjustRemaining adds delta (negative to subtract) to the time left on t,
+// clamped at 1 second like adjustDuration. Done timers can only be given more
+// time, which starts them again. It reports whether t changed.
+func (t *Timer) adjustRemaining(now time.Time, delta time.Duration) bool {
+	remaining := t.remainingAt(now)
+	if remaining <= 0 {
+		if delta <= 0 {
+			return false
+		}
+		remaining = 0
+	}
+	remaining = max(remaining+delta, time.Second)
+	if t.Paused {
+		t.Remaining = remaining
+		return true
+	}
+	t.End = now.Add(t.toRealTime(remaining))
+	t.Notified = false
+	return true
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// atJob is one scheduled job from atq
+type atJob struct {
+	ID   string
+	When time.Time
+	Name string
+}
+
+// atqTimeLayout is the time format atq prints, e.g. "Thu Oct 17 10:00:00 2026"
+const atqTimeLayout = "Mon Jan _2 15:04:05 2006"
+
+// parseAtq parses atq output ("<id>\t<date> <queue> <user>"). Any text after the
+// user is used as the job name, which lets hand-written files name their jobs.
+// Lines that cannot be parsed are returned as skipped.
+func parseAtq(output string, loc *time.Location) (jobs []atJob, skipped []string) {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			skipped = append(skipped, line)
+			continue
+		}
+		when, err := time.ParseInLocation(atqTimeLayout, strings.Join(fields[1:6], " "), loc)
+		if err != nil {
+			skipped = append(skipped, line)
+			continue
+		}
+		job := atJob{ID: fields[0], When: when}
+		if len(fields) > 8 {
+			job.Name = strings.Join(fields[8:], " ")
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, skipped
+}
+
+// atJobName picks a name from an "at -c" job script: the first comment in the
+// job body, or else its first command
+func atJobName(script string) string {
+	lines := strings.Split(script, "\n")
+
+	// at wraps the user's commands in a heredoc after the environment setup;
+	// without one, the commands follow the last closing brace
+	start, delim := 0, ""
+	for i, line := range lines {
+		if idx := strings.Index(line, "<< '"); idx >= 0 {
+			start = i + 1
+			delim = strings.TrimSuffix(line[idx+4:], "'")
+		} else if delim == "" && strings.TrimSpace(line) == "}" {
+			start = i + 1
+		}
+	}
+
+	command := ""
+	for _, line := range lines[start:] {
+		line = strings.TrimSpace(line)
+		if delim != "" && line == delim {
+			break
+		}
+		if line == "" {
+			continue
+		}
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			if comment = strings.TrimSpace(comment); comment != "" {
+				return comment
+			}
+			continue
+		}
+		if command == "" {
+			command = line
+		}
+	}
+	return command
+}
+
+// importAtJobs reads jobs from file, or from atq and "at -c" when file is empty,
+// and returns timers for those still in the future along with a report line per
+// skipped job
+func importAtJobs(file string, now time.Time) ([]Timer, []string, error) {
+	var output []byte
+	var err error
+	if file != "" {
+		output, err = os.ReadFile(file)
+	} else {
+		output, err = exec.Command("atq").Output()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading at jobs: %w", err)
+	}
+
+	jobs, bad := parseAtq(string(output), now.Location())
+	var report []string
+	for _, line := range bad {
+		report = append(report, fmt.Sprintf("unrecognized line %q", line))
+	}
+
+	var timers []Timer
+	for _, job := range jobs {
+		if !job.When.After(now) {
+			report = append(report, fmt.Sprintf("job %s at %s has already passed", job.ID, job.When.Format("2006-01-02 15:04")))
+			continue
+		}
+		name := job.Name
+		if name == "" && file == "" {
+			if script, err := exec.Command("at", "-c", job.ID).Output(); err == nil {
+				name = atJobName(string(script))
+			}
+		}
+		if name == "" {
+			name = "at job " + job.ID
+		}
+		timers = append(timers, Timer{
+			ID:       newTimerID(),
+			Name:     name,
+			End:      job.When,
+			Duration: job.When.Sub(now),
+			Created:  now,
+			Started:  now,
+		})
+	}
+	return timers, report, nil
+}
@@ -1,13 +1,74 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
+// cliCommands lists the full names of all CLI commands
+var cliCommands = []string{"add", "add-many", "list", "next", "total", "pause", "resume", "delete", "restart", "reset", "edit", "duplicate", "move", "pin", "unpin", "snooze", "pomodoro", "billing", "export", "import", "import-at", "tray", "serve", "metrics", "profiles", "version", "help"}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// suggestCommands returns the full command names closest to an unknown command,
+// considering both commands and their shortcuts
+func suggestCommands(cmd string) []string {
+	best := -1
+	var matches []string
+	consider := func(name, full string) {
+		d := levenshtein(cmd, name)
+		// Ignore matches that would rewrite the whole input
+		if d > 2 || d >= len(cmd) {
+			return
+		}
+		if best == -1 || d < best {
+			best = d
+			matches = nil
+		}
+		if d == best && !slices.Contains(matches, full) {
+			matches = append(matches, full)
+		}
+	}
+	for _, c := range cliCommands {
+		consider(c, c)
+	}
+	for alias, full := range commandAliases {
+		consider(alias, full)
+	}
+	sort.Strings(matches)
+	return matches
+}
+
 func printUsage() {
 	fmt.Println("go-countdown - Terminal countdown timer")
 	fmt.Println()
@@ -17,12 +78,60 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("COMMANDS:")
 	fmt.Println("  add <name> <duration>           Add a new timer")
-	fmt.Println("  list [--filter]                 List timers (filter: --active, --paused, --done)")
+	fmt.Println("  add <name>                      Add a timer of defaultDuration from the config")
+	fmt.Println("  add <name> <duration> --every <interval>  Add a timer that repeats every interval")
+	fmt.Println("  add <name> --weekdays <days> <HH:MM>  Add a timer repeating on weekdays (e.g. MWF)")
+	fmt.Println("  add <name> --until <time>       Count down to 17:00 (next occurrence) or 2025-06-01T09:00")
+	fmt.Println("  add <name> --at <phrase>        Count down to \"tomorrow 9am\", \"next monday 14:00\", \"friday noon\"")
+	fmt.Println("  add <name> --event <event>      Count down to an event (newyear, christmas, ... or from config)")
+	fmt.Println("  add <name> <duration> --watch   Add a timer and open the TUI on it")
+	fmt.Println("  add <name> <duration> --yes     Skip the long duration confirmation")
+	fmt.Println("  add <name> <duration> --paused  Add the timer paused; resume starts it")
+	fmt.Println("  add <name> <duration> --speed <factor>  Count down faster (2) or slower (0.5) than real time")
+	fmt.Println("  add <name> <duration> --rate <per-hour> [--billable]  Track billable time at an hourly rate")
+	fmt.Println("  add <name> <duration> --tag <tag>  Tag the timer (repeatable, adds to default tags)")
+	fmt.Println("  add <name> <duration> --session-tag <tag>  Extra default tag (also GO_COUNTDOWN_SESSION_TAG)")
+	fmt.Println("  add <name> <duration> --color <color>  Color of the tag label (e.g. red, 205, #ff8800)")
+	fmt.Println("  add <name> <duration> --note <text>  Attach a note (a URL, a description)")
+	fmt.Println("  add <name> --before-sunset|--after-sunrise <offset>  Add a timer relative to the sun")
+	fmt.Println("  add-many [--tag <tag>] [\"Name|30m\" ...]  Add several timers at once (stdin lines when no args)")
+	fmt.Println("  list [--filter]                 List timers (filter: --active, --paused, --done, --tag=<tag>)")
+	fmt.Println("  list [--filter] --json          Print timers as JSON (name, status, remaining and end)")
+	fmt.Println("  list --sort=<key> [--reverse]   Sort by name, remaining, end, duration or created")
+	fmt.Println("  list [--filter] --count         Print only the number of matching timers")
+	fmt.Println("  list [--filter] --compact       One \"name remaining\" line per timer, no header")
+	fmt.Println("  list [--filter] --porcelain     Stable tab-separated lines: id, status, name, remaining_seconds, end_epoch")
+	fmt.Println("  list [--filter] --watch         Redraw the list every second until Ctrl+C")
+	fmt.Println("  list [--filter] --watch --json  Stream one JSON array per line every second")
+	fmt.Println("  next [--json]                   Print the running timer that ends soonest (\"none\" if idle)")
+	fmt.Println("  total [--filter]                Print the combined time left on matching timers")
 	fmt.Println("  pause [--all] [filter] <index>  Pause timer(s) by 1-based index, or --all")
 	fmt.Println("  resume [--all] [filter] <index> Resume timer(s) by 1-based index, or --all")
 	fmt.Println("  delete [--done|--all] [filter] <index>  Delete timer(s) by index, --done, or --all")
-	fmt.Println("  restart [--all|--active|--paused] [filter] <index>  Restart timer(s)")
+	fmt.Println("  delete --dry-run ...            List the timers a delete would remove, without deleting")
+	fmt.Println("  restart [--all|--active|--paused] [--keep-paused] [filter] <index>  Restart timer(s)")
+	fmt.Println("  reset [filter] <index>          Reset a timer to its full duration, paused (restart sets it running)")
+	fmt.Println("  pause|resume|delete|restart|reset --name <name>  Pick the timer by exact name instead of index")
 	fmt.Println("  edit [filter] <index> <name> <duration>  Edit timer")
+	fmt.Println("  edit --name <name> <new name> [duration]  Edit the timer with this exact name")
+	fmt.Println("  edit ... <duration> --adjust    Change the duration keeping progress (end moves by the difference)")
+	fmt.Println("  edit [filter] <index> --note <text>  Set a timer's note (\"\" clears it)")
+	fmt.Println("  edit [filter] --add|--sub <duration>  Add or take time off every matching timer (at least 1s stays)")
+	fmt.Println("  move <from> <to>                Move a timer to another position, shifting the rest")
+	fmt.Println("  pin|unpin [filter] <index>      Keep a timer above the rest whatever the sort (also --name)")
+	fmt.Println("  duplicate [filter] <index>      Copy a timer (\"<name> (copy)\") and start the copy")
+	fmt.Println("  pomodoro [--work 25m] [--break 5m] [--rounds 4]  Add chained work/break timers; each starts when the last ends")
+	fmt.Println("  snooze [filter] <index> [duration]  Give a timer more time (default: snoozeStep from config)")
+	fmt.Println("  billing [--filter]              Show accrued cost of billable timers grouped by first tag")
+	fmt.Println("  export [--filter] --ics         Print running timers as an iCalendar (.ics) file")
+	fmt.Println("  export [--filter] --csv         Print timers as CSV (name, status, duration, remaining, end)")
+	fmt.Println("  import <file.json> [--replace]  Add timers from a saved timers file (--replace overwrites)")
+	fmt.Println("  import-at [file]                Create timers from pending at jobs (atq, or atq-style file)")
+	fmt.Println("  tray                            Show the next timer in the system tray (build with -tags tray)")
+	fmt.Println("  serve [--port 8080] [--host 127.0.0.1]  Serve the timers as a local JSON API (see README)")
+	fmt.Println("  metrics [--filter]              Print Prometheus metrics (remaining seconds, counts by status)")
+	fmt.Println("  profiles                        List timer sets (--profile names); * marks the one in use")
+	fmt.Println("  version                         Show the version (also --version, -v)")
 	fmt.Println("  help                            Show this help")
 	fmt.Println()
 	fmt.Println("COMMAND SHORTCUTS:")
@@ -35,6 +144,8 @@ func printUsage() {
 	fmt.Println("  e         edit")
 	fmt.Println("  h         help")
 	fmt.Println()
+	fmt.Println("  Mistyped commands get a suggestion; add --auto-correct to run it directly.")
+	fmt.Println()
 	fmt.Println("BULK OPERATIONS:")
 	fmt.Println("  pause --all              Pause all active timers")
 	fmt.Println("  resume --all             Resume all paused timers")
@@ -43,17 +154,31 @@ func printUsage() {
 	fmt.Println("  restart --all            Restart all timers")
 	fmt.Println("  restart --active         Restart all active timers")
 	fmt.Println("  restart --paused         Restart all paused timers")
+	fmt.Println("  restart --paused --keep-paused  Reset paused timers to full duration, still paused")
+	fmt.Println()
+	fmt.Println("GLOBAL FLAGS (before the command):")
+	fmt.Println("  --data-file <path>       Use another timers file (also GO_COUNTDOWN_DATA)")
+	fmt.Println("  --profile <name>         Use the named timer set (timers-<name>.json in the config dir)")
+	fmt.Println("  --config <path>          Use another config file")
+	fmt.Println("  --quiet, -q              Only print errors and command output like list")
+	fmt.Println("  --no-color               Plain TUI output without colors or styles (also NO_COLOR)")
 	fmt.Println()
 	fmt.Println("DURATION FORMAT:")
 	fmt.Println("  30s    30 seconds")
 	fmt.Println("  5m     5 minutes")
 	fmt.Println("  1h     1 hour")
 	fmt.Println("  2d     2 days")
+	fmt.Println("  3w     3 weeks")
+	fmt.Println("  2mo    2 months (30 days each)")
 	fmt.Println("  1y     1 year")
+	fmt.Println("  1.5h   Decimal: 1 hour 30 minutes")
 	fmt.Println("  30d30m Compound: 30 days 30 minutes")
+	fmt.Println("  3*(25m+5m)  Expression: 90 minutes (supports +, -, * and parentheses)")
 	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  go-countdown a \"Meeting\" 30m")
+	fmt.Println("  go-countdown a \"Golden hour\" --before-sunset 30m")
+	fmt.Println("  go-countdown a \"Standup\" --weekdays MTWRF 09:00")
 	fmt.Println("  go-countdown l                    # List all timers")
 	fmt.Println("  go-countdown l --active           # List only active timers")
 	fmt.Println("  go-countdown p 1                  # Pause first timer")
@@ -84,10 +209,143 @@ func parseFilterAndIndex(args []string) (filter, indexStr string, idx int) {
 	return filter, indexStr, idx
 }
 
+// takeFlag returns the value of "--flag value" or "--flag=value" and args without it.
+// The value is empty when the flag is absent; if repeated, the last one wins.
+func takeFlag(args []string, flag string) (string, []string, error) {
+	values, rest, err := takeFlagValues(args, flag)
+	if err != nil || len(values) == 0 {
+		return "", rest, err
+	}
+	return values[len(values)-1], rest, nil
+}
+
+// quietOutput suppresses the informational messages printed by CLI commands
+var quietOutput bool
+
+// noColor renders the TUI without colors or other styling (--no-color or NO_COLOR)
+var noColor bool
+
+// infof prints an informational message unless --quiet is set
+func infof(format string, a ...any) {
+	if !quietOutput {
+		fmt.Printf(format, a...)
+	}
+}
+
+// globalValueFlags and globalBoolFlags are the flags takeGlobalFlags applies
+var (
+	globalValueFlags = []string{"--data-file", "--profile", "--config"}
+	globalBoolFlags  = []string{"--quiet", "-q", "--no-color"}
+)
+
+// globalFlagsEnd returns the index of the first arg after the leading global
+// flags, which is the command name or "--"
+func globalFlagsEnd(args []string) int {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case slices.Contains(globalValueFlags, a):
+			i++ // skip the value
+		case slices.Contains(globalBoolFlags, a):
+		case slices.ContainsFunc(globalValueFlags, func(f string) bool { return strings.HasPrefix(a, f+"=") }):
+		default:
+			return i
+		}
+	}
+	return len(args)
+}
+
+// takeGlobalFlags applies --data-file, --profile, --config, --quiet and
+// --no-color given before the command name and returns the remaining args.
+// Later args are left to the command, so a note or name like "-q" is kept.
+func takeGlobalFlags(args []string) ([]string, error) {
+	end := globalFlagsEnd(args)
+	args, rest := args[:end], args[end:]
+	dataFile, args, err := takeFlag(args, "--data-file")
+	if err != nil {
+		return nil, err
+	}
+	profile, args, err := takeFlag(args, "--profile")
+	if err != nil {
+		return nil, err
+	}
+	if dataFile != "" && profile != "" {
+		return nil, fmt.Errorf("use only one of --data-file and --profile")
+	}
+	if profile != "" {
+		if dataFile, err = profileFile(profile); err != nil {
+			return nil, err
+		}
+	}
+	config, args, err := takeFlag(args, "--config")
+	if err != nil {
+		return nil, err
+	}
+	quiet, args := takeBoolFlag(args, "--quiet")
+	q, args := takeBoolFlag(args, "-q")
+	quietOutput = quiet || q
+	plain, args := takeBoolFlag(args, "--no-color")
+	// Any non-empty NO_COLOR disables colors (https://no-color.org)
+	if plain || os.Getenv("NO_COLOR") != "" {
+		noColor = true
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	if dataFile != "" {
+		saveFile = dataFile
+	}
+	if config != "" {
+		configFile = config
+	}
+	return slices.Concat(args, rest), nil
+}
+
+// takeFlagValues returns every value of a repeatable flag and args without them
+func takeFlagValues(args []string, flag string) ([]string, []string, error) {
+	rest := make([]string, 0, len(args))
+	var values []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == flag:
+			if i+1 >= len(args) {
+				return nil, args, fmt.Errorf("flag %s requires a value", flag)
+			}
+			values = append(values, args[i+1])
+			i++
+		case strings.HasPrefix(a, flag+"="):
+			values = append(values, strings.TrimPrefix(a, flag+"="))
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return values, rest, nil
+}
+
+// takeBoolFlag reports whether the flag is present and returns args without it
+func takeBoolFlag(args []string, flag string) (bool, []string) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for _, a := range args {
+		if a == flag {
+			found = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return found, rest
+}
+
 func getFilteredTimers(timers []Timer, filter string) []Timer {
-	now := time.Now()
+	now := nowFunc()
+	tag, byTag := strings.CutPrefix(filter, "--tag=")
 	var result []Timer
 	for _, t := range timers {
+		if byTag {
+			if slices.Contains(t.Tags, tag) {
+				result = append(result, t)
+			}
+			continue
+		}
 		switch filter {
 		case "--active":
 			if !t.Paused && t.End.After(now) {
@@ -118,39 +376,108 @@ func resolveIndex(timers []Timer, filter string, idx int) (int, error) {
 	}
 	targetTimer := filtered[idx-1]
 	for i, t := range timers {
-		if t.Name == targetTimer.Name && t.End.Equal(targetTimer.End) {
+		if t.ID == targetTimer.ID {
 			return i, nil
 		}
 	}
 	return -1, fmt.Errorf("timer not found")
 }
 
-func formatEndTimeCLI(end, now time.Time) string {
-	if end.Day() == now.Day() && end.Month() == now.Month() && end.Year() == now.Year() {
-		return end.Format("15:04:05")
-	} else if end.Month() == now.Month() && end.Year() == now.Year() {
-		return end.Format("Jan 2 15:04")
-	} else if end.Year() == now.Year() {
-		return end.Format("Jan 2")
-	} else {
-		return end.Format("2006-01-02")
+// resolveName returns the actual index of the timer with exactly this name
+func resolveName(timers []Timer, name string) (int, error) {
+	found := -1
+	for i, t := range timers {
+		if t.Name != name {
+			continue
+		}
+		if found >= 0 {
+			return -1, fmt.Errorf("more than one timer is named %q; use its index instead (see list)", name)
+		}
+		found = i
+	}
+	if found < 0 {
+		return -1, fmt.Errorf("no timer named %q", name)
 	}
+	return found, nil
 }
 
-func listTimers(timers []Timer, filter string) {
-	now := time.Now()
+// resolveTarget finds the timer a single-timer command acts on: by --name
+// when given, otherwise by [filter] <index> in args
+func resolveTarget(timers []Timer, name string, args []string) (int, error) {
+	if name != "" {
+		return resolveName(timers, name)
+	}
+	filter, _, idx := parseFilterAndIndex(args)
+	return resolveIndex(timers, filter, idx)
+}
+
+// uniqueName returns name, or name with the first free " (n)" suffix if taken
+func uniqueName(name string, taken map[string]bool) string {
+	if !taken[name] {
+		return name
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", name, n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// cliEndTimeLayouts are the end time layouts for list, which spell out the
+// month; the end time formats in the config replace them
+var cliEndTimeLayouts = endTimeLayouts{"15:04:05", "Jan 2 15:04", "Jan 2", "2006-01-02"}
+
+// sortListed orders timers for output like the TUI: sortTimers, then done
+// timers last when the doneLast config is set, then pinned timers first
+func sortListed(timers []Timer, order timerSort, reverse bool, now time.Time) {
+	sortTimers(timers, order, reverse, now)
+	if cfg, err := loadConfig(); err == nil && cfg.DoneLast {
+		partitionDone(timers, now)
+	}
+	partitionPinned(timers)
+}
+
+// listTimers prints the filtered timers in the given order. Each keeps the
+// index it has under the filter so it can be passed to other commands.
+func listTimers(timers []Timer, filter string, order timerSort, reverse, compact bool) {
 	filtered := getFilteredTimers(timers, filter)
+	indexes := make(map[string]int, len(filtered))
+	for i, t := range filtered {
+		indexes[t.ID] = i + 1
+	}
+	printTimers(filtered, indexes, order, reverse, compact)
+}
+
+// printTimers prints timers in the given order, each labelled with its index
+// from indexes
+func printTimers(timers []Timer, indexes map[string]int, order timerSort, reverse, compact bool) {
+	now := nowFunc()
+	sortListed(timers, order, reverse, now)
+
+	loc, layouts := time.Local, cliEndTimeLayouts
+	if cfg, err := loadConfig(); err == nil {
+		loc, layouts = cfg.displayLocation(), cfg.endTimeLayouts(cliEndTimeLayouts)
+	}
+
+	// One "name remaining" line per timer, for status bars
+	if compact {
+		for _, t := range timers {
+			fmt.Println(t.Name, t.StatusText(now))
+		}
+		return
+	}
 
 	fmt.Println("Countdown Timers")
 	fmt.Println("================")
 	fmt.Println()
 
-	if len(filtered) == 0 {
+	if len(timers) == 0 {
 		fmt.Println("No timers found.")
 		return
 	}
 
-	for i, t := range filtered {
+	for _, t := range timers {
 		var statusEmoji, remainingText, endTimeText string
 
 		if t.Paused {
@@ -158,27 +485,235 @@ func listTimers(timers []Timer, filter string) {
 			remainingText = formatDuration(t.Remaining)
 			endTimeText = ""
 		} else {
-			remaining := time.Until(t.End)
+			remaining := t.remainingAt(now)
 			if remaining <= 0 {
 				statusEmoji = "[done]"
 				remainingText = "Done"
-				elapsed := t.Duration - remaining
-				endTimeText = fmt.Sprintf("(+%s elapsed)", formatDuration(elapsed))
+				endTimeText = fmt.Sprintf("(done %s)", formatAgo(t.doneFor(now)))
 			} else {
 				statusEmoji = "[active]"
 				remainingText = formatDuration(remaining)
-				endTimeText = fmt.Sprintf("(ends %s)", formatEndTimeCLI(t.End, now))
+				endTimeText = fmt.Sprintf("(ends %s)", formatEndTime(t.End, now, loc, layouts))
 			}
 		}
 
-		fmt.Printf("[%d] %s %-30s %-13s", i+1, statusEmoji, t.Name, remainingText)
+		fmt.Printf("[%d] %s %-30s %-13s", indexes[t.ID], statusEmoji, t.Name, remainingText)
 		if endTimeText != "" {
 			fmt.Printf(" %s", endTimeText)
 		}
+		if t.Pinned {
+			fmt.Print(" [pinned]")
+		}
+		if t.Repeat > 0 {
+			fmt.Printf(" [every %s]", formatDuration(t.Repeat))
+		}
+		if t.Weekdays != 0 {
+			fmt.Printf(" [%s %s]", t.Weekdays, t.TimeOfDay)
+		}
+		if t.speed() != 1 {
+			fmt.Printf(" [%gx speed]", t.speed())
+		}
+		for _, tag := range t.Tags {
+			fmt.Printf(" #%s", tag)
+		}
 		fmt.Println()
+		if t.Note != "" {
+			fmt.Printf("    %s\n", t.Note)
+		}
 	}
 
-	fmt.Printf("\nShowing %d timer(s)\n", len(filtered))
+	fmt.Printf("\nShowing %d timer(s)\n", len(timers))
+}
+
+// followList redraws the list every second, rereading the timers file so
+// changes from other commands show up, until interrupted. With asJSON it
+// streams one JSON array per line instead, for tools tailing the output.
+func followList(filter string, order timerSort, reverse, compact, asJSON bool) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	if !asJSON {
+		fmt.Print("\x1b[?25l")       // hide the cursor while redrawing
+		defer fmt.Print("\x1b[?25h") // and always give it back
+	}
+	for {
+		timers, err := loadTimers()
+		switch {
+		case err != nil && !os.IsNotExist(err):
+			fmt.Fprintf(os.Stderr, "error loading timers: %v\n", err)
+		case asJSON:
+			// Stdout is unbuffered, so each line is out as soon as it is written
+			b, err := json.Marshal(timersJSON(timers, filter, order, reverse))
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+		default:
+			fmt.Print("\x1b[H\x1b[2J")
+			listTimers(timers, filter, order, reverse, compact)
+		}
+
+		select {
+		case <-interrupt:
+			if !asJSON {
+				fmt.Println()
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// timerJSON is the machine-readable form of a timer printed by "list --json"
+type timerJSON struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Paused           bool    `json:"paused"`
+	RemainingSeconds float64 `json:"remainingSeconds"`
+	End              string  `json:"end"`
+	DurationSeconds  float64 `json:"durationSeconds"`
+	Status           string  `json:"status"`
+	Note             string  `json:"note,omitempty"`
+	Started          string  `json:"started,omitempty"`
+}
+
+// porcelainCleaner keeps names from breaking the porcelain line format
+var porcelainCleaner = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+// printPorcelain prints one tab-separated line per filtered timer: id,
+// status, name, remaining seconds and end as a Unix time (0 while paused).
+// The columns are a contract for scripts; add new ones only at the end.
+func printPorcelain(timers []Timer, filter string, order timerSort, reverse bool) {
+	now := nowFunc()
+	filtered := getFilteredTimers(timers, filter)
+	sortListed(filtered, order, reverse, now)
+
+	for _, t := range filtered {
+		remaining := max(t.remainingAt(now), 0)
+		var end int64
+		if t.Paused {
+			remaining = t.Remaining
+		} else {
+			end = t.End.Unix()
+		}
+		fmt.Printf("%s\t%s\t%s\t%d\t%d\n", t.ID, t.status(now), porcelainCleaner.Replace(t.Name),
+			int64(remaining.Round(time.Second).Seconds()), end)
+	}
+}
+
+// printTimersJSON prints the filtered timers as a JSON array in the given order
+func printTimersJSON(timers []Timer, filter string, order timerSort, reverse bool) error {
+	return printJSON(timersJSON(timers, filter, order, reverse))
+}
+
+// timersJSON converts the filtered timers to their JSON form in the given order
+func timersJSON(timers []Timer, filter string, order timerSort, reverse bool) []timerJSON {
+	now := nowFunc()
+	filtered := getFilteredTimers(timers, filter)
+	sortListed(filtered, order, reverse, now)
+
+	out := []timerJSON{}
+	for _, t := range filtered {
+		out = append(out, newTimerJSON(t, now))
+	}
+	return out
+}
+
+func newTimerJSON(t Timer, now time.Time) timerJSON {
+	remaining := max(t.remainingAt(now), 0)
+	started := ""
+	if !t.Started.IsZero() {
+		started = t.Started.Format(time.RFC3339)
+	}
+	return timerJSON{
+		ID:               t.ID,
+		Name:             t.Name,
+		Paused:           t.Paused,
+		RemainingSeconds: remaining.Round(time.Second).Seconds(),
+		End:              t.End.Format(time.RFC3339),
+		DurationSeconds:  t.Duration.Round(time.Second).Seconds(),
+		Status:           t.status(now),
+		Note:             t.Note,
+		Started:          started,
+	}
+}
+
+func printJSON(v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// printNextTimer prints the running timer that ends soonest as "Name — 12m",
+// or "none" (null with --json) when nothing is running
+func printNextTimer(timers []Timer, asJSON bool) error {
+	now := nowFunc()
+	t, ok := soonestActive(timers, now)
+	if asJSON {
+		if !ok {
+			return printJSON(nil)
+		}
+		return printJSON(newTimerJSON(t, now))
+	}
+	if !ok {
+		fmt.Println("none")
+		return nil
+	}
+	fmt.Printf("%s — %s\n", t.Name, formatDuration(t.remainingAt(now)))
+	return nil
+}
+
+// printBilling reports the accrued cost of billable timers, grouped by their
+// first tag (used as the client/project) so each timer is counted once
+func printBilling(timers []Timer, filter string) {
+	now := nowFunc()
+
+	groups := make(map[string][]Timer)
+	var names []string
+	for _, t := range getFilteredTimers(timers, filter) {
+		if !t.Billable {
+			continue
+		}
+		group := "(untagged)"
+		if len(t.Tags) > 0 {
+			group = t.Tags[0]
+		}
+		if _, ok := groups[group]; !ok {
+			names = append(names, group)
+		}
+		groups[group] = append(groups[group], t)
+	}
+
+	fmt.Println("Billing")
+	fmt.Println("=======")
+	fmt.Println()
+
+	if len(names) == 0 {
+		fmt.Println("No billable timers found.")
+		return
+	}
+
+	sort.Strings(names)
+	total := 0.0
+	for _, group := range names {
+		fmt.Println(group)
+		subtotal := 0.0
+		for _, t := range groups[group] {
+			cost := t.costAt(now)
+			subtotal += cost
+			fmt.Printf("  %-30s %-13s @ %8.2f/h %10.2f\n", t.Name, formatDuration(t.elapsedAt(now)), t.Rate, cost)
+		}
+		fmt.Printf("  %-30s %37.2f\n\n", "Subtotal", subtotal)
+		total += subtotal
+	}
+	fmt.Printf("%-32s %37.2f\n", "Total", total)
 }
 
 func executeCLICommand(cmd string, args []string) error {
@@ -187,118 +722,433 @@ func executeCLICommand(cmd string, args []string) error {
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("error loading timers: %w", err)
 	}
-	dirty := false
+	// Scheduled timers that completed while nothing was running move on first
+	dirty := rollRecurring(timers, nowFunc())
+	if advanceChains(timers, nowFunc()) {
+		dirty = true
+	}
+	// Done timers past the configured retention go before indexes are resolved
+	if cfg, err := loadConfig(); err == nil {
+		if kept, n := removeExpiredDone(timers, nowFunc(), cfg.autoDeleteAfter()); n > 0 {
+			timers = kept
+			dirty = true
+		}
+	}
+	var watchTimer *Timer // timer to select when launching the TUI after the command
 
 	switch cmd {
 	case "add":
-		if len(args) < 2 {
+		beforeSunset, args, err := takeFlag(args, "--before-sunset")
+		if err != nil {
+			return err
+		}
+		afterSunrise, args, err := takeFlag(args, "--after-sunrise")
+		if err != nil {
+			return err
+		}
+		if beforeSunset != "" && afterSunrise != "" {
+			return fmt.Errorf("use only one of --before-sunset and --after-sunrise")
+		}
+		sunMode := beforeSunset != "" || afterSunrise != ""
+		eventName, args, err := takeFlag(args, "--event")
+		if err != nil {
+			return err
+		}
+		until, args, err := takeFlag(args, "--until")
+		if err != nil {
+			return err
+		}
+		at, args, err := takeFlag(args, "--at")
+		if err != nil {
+			return err
+		}
+		if until != "" && at != "" {
+			return fmt.Errorf("use only one of --until and --at")
+		}
+		weekdaySpec, args, err := takeFlag(args, "--weekdays")
+		if err != nil {
+			return err
+		}
+		everyStr, args, err := takeFlag(args, "--every")
+		if err != nil {
+			return err
+		}
+		tags, args, err := takeFlagValues(args, "--tag")
+		if err != nil {
+			return err
+		}
+		sessionTags, args, err := takeFlagValues(args, "--session-tag")
+		if err != nil {
+			return err
+		}
+		speedStr, args, err := takeFlag(args, "--speed")
+		if err != nil {
+			return err
+		}
+		color, args, err := takeFlag(args, "--color")
+		if err != nil {
+			return err
+		}
+		note, args, err := takeFlag(args, "--note")
+		if err != nil {
+			return err
+		}
+		rateStr, args, err := takeFlag(args, "--rate")
+		if err != nil {
+			return err
+		}
+		billable, args := takeBoolFlag(args, "--billable")
+		watch, args := takeBoolFlag(args, "--watch")
+		yes, args := takeBoolFlag(args, "--yes")
+		paused, args := takeBoolFlag(args, "--paused")
+
+		speed := 0.0
+		if speedStr != "" {
+			speed, err = strconv.ParseFloat(speedStr, 64)
+			if err != nil || speed <= 0 {
+				return fmt.Errorf("invalid speed: %s (use a positive number like 2 or 0.5)", speedStr)
+			}
+			if sunMode || weekdaySpec != "" || eventName != "" || until != "" || at != "" {
+				return fmt.Errorf("--speed only applies to duration timers")
+			}
+		}
+
+		var repeat time.Duration
+		if everyStr != "" {
+			repeat, err = parseDuration(everyStr)
+			if err != nil {
+				return fmt.Errorf("invalid --every interval: %w", err)
+			}
+			if weekdaySpec != "" {
+				return fmt.Errorf("use only one of --every and --weekdays")
+			}
+		}
+
+		rate := 0.0
+		if rateStr != "" {
+			rate, err = strconv.ParseFloat(rateStr, 64)
+			if err != nil || rate < 0 {
+				return fmt.Errorf("invalid rate: %s", rateStr)
+			}
+			// Setting a rate implies the timer is billable
+			billable = true
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			cfg = defaultConfig()
+		}
+
+		// Plain duration timers may leave the duration to defaultDuration
+		needsSecondArg := !sunMode && eventName == "" && until == "" && at == "" &&
+			(cfg.DefaultDuration == "" || weekdaySpec != "")
+		if len(args) < 1 || (len(args) < 2 && needsSecondArg) {
 			fmt.Println("Usage: go-countdown add <name> <duration>")
-			fmt.Println("\nDuration examples: 30s, 5m, 1h, 2d, 1y, 30d30m, 1h30m")
+			fmt.Println("       go-countdown add <name> --before-sunset <offset>|--after-sunrise <offset>")
+			fmt.Println("       go-countdown add <name> --weekdays <days> <HH:MM>")
+			fmt.Println("       go-countdown add <name> --event <event>")
+			fmt.Println("       go-countdown add <name> --until <HH:MM|YYYY-MM-DDTHH:MM>")
+			fmt.Println("       go-countdown add <name> --at \"tomorrow 9am\"")
+			fmt.Println("\nDuration examples: 30s, 5m, 1h, 2d, 3w, 1y, 30d30m, 1h30m")
+			fmt.Println("Weekdays: M T W R F S U (R = Thursday, U = Sunday) or daily, e.g. MWF")
 			return nil
 		}
 		name := args[0]
-		duration := args[1]
-		d, err := parseDuration(duration)
-		if err != nil {
-			return fmt.Errorf("invalid duration: %w", err)
+
+		now := nowFunc()
+		var end time.Time
+		var d time.Duration
+		var weekdays weekdayMask
+		var timeOfDay string
+		if until != "" {
+			end, err = parseUntil(until, now)
+			if err != nil {
+				return err
+			}
+			d = end.Sub(now)
+		} else if at != "" {
+			end, err = parseNaturalTime(at, now)
+			if err != nil {
+				return err
+			}
+			d = end.Sub(now)
+		} else if eventName != "" {
+			end, err = nextEventOccurrence(eventName, cfg.Events, now)
+			if err != nil {
+				return err
+			}
+			d = end.Sub(now)
+		} else if weekdaySpec != "" {
+			// The second argument is the clock time instead of a duration
+			weekdays, err = parseWeekdays(weekdaySpec)
+			if err != nil {
+				return err
+			}
+			timeOfDay = args[1]
+			end, err = nextWeekdayOccurrence(weekdays, timeOfDay, now)
+			if err != nil {
+				return err
+			}
+			d = end.Sub(now)
+		} else if sunMode {
+			end, err = sunTarget(beforeSunset, afterSunrise, now)
+			if err != nil {
+				return err
+			}
+			d = end.Sub(now)
+		} else {
+			durationStr := cfg.DefaultDuration
+			if len(args) > 1 {
+				durationStr = args[1]
+			}
+			d, err = parseDuration(durationStr)
+			if err != nil {
+				return fmt.Errorf("invalid duration: %w", err)
+			}
+			end = now.Add(Timer{Speed: speed}.toRealTime(d))
 		}
+
+		// Catch typos like "2y" for "2d" before creating the timer
+		if cfg.ConfirmLongDurations && d > cfg.longDurationThreshold() && !yes {
+			fmt.Printf("This will end on %s (%s). Continue? [y/N]: ", formatEndTime(end, now, cfg.displayLocation(), cfg.endTimeLayouts(cliEndTimeLayouts)), formatDuration(d))
+			var response string
+			_, _ = fmt.Scanln(&response)
+			if strings.ToLower(response) != "y" {
+				fmt.Println("Cancelled")
+				return nil
+			}
+		}
+
 		newTimer := Timer{
-			Name:     name,
-			End:      time.Now().Add(d),
-			Duration: d,
+			ID:        newTimerID(),
+			Name:      name,
+			End:       end,
+			Duration:  d,
+			Created:   now,
+			Started:   now,
+			Repeat:    repeat,
+			Weekdays:  weekdays,
+			TimeOfDay: timeOfDay,
+			Tags:      mergeTags(cfg.newTimerTags(), sessionTags, tags),
+			Color:     color,
+			Note:      note,
+			Speed:     speed,
+			Billable:  billable,
+			Rate:      rate,
+		}
+		if paused {
+			// Resuming starts the clock from the full duration
+			newTimer.Paused = true
+			newTimer.Remaining = d
+			newTimer.Started = time.Time{}
 		}
 		timers = append(timers, newTimer)
 		dirty = true
-		fmt.Printf("Added timer \"%s\" (%s)\n", name, formatDuration(d))
+		if paused {
+			infof("Added paused timer \"%s\" (%s)\n", name, formatDuration(d))
+		} else {
+			infof("Added timer \"%s\" (%s)\n", name, formatDuration(d))
+		}
+		if watch {
+			watchTimer = &newTimer
+		}
+
+	case "add-many":
+		tags, args, err := takeFlagValues(args, "--tag")
+		if err != nil {
+			return err
+		}
+		specs := args
+		if len(specs) == 0 {
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				specs = append(specs, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("error reading timers: %w", err)
+			}
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			cfg = defaultConfig()
+		}
+		added, failed := parseTimerSpecs(specs, mergeTags(cfg.newTimerTags(), tags), nowFunc())
+		for _, t := range added {
+			infof("Added timer \"%s\" (%s)\n", t.Name, formatDuration(t.Duration))
+		}
+		for _, f := range failed {
+			infof("Skipped %s\n", f)
+		}
+		if len(added) > 0 {
+			timers = append(timers, added...)
+			dirty = true
+		}
+		infof("Added %d timer(s), %d failed\n", len(added), len(failed))
 
 	case "list":
+		asJSON, args := takeBoolFlag(args, "--json")
+		count, args := takeBoolFlag(args, "--count")
+		compact, args := takeBoolFlag(args, "--compact")
+		porcelain, args := takeBoolFlag(args, "--porcelain")
+		follow, args := takeBoolFlag(args, "--watch")
+		reverse, args := takeBoolFlag(args, "--reverse")
+		sortStr, args, err := takeFlag(args, "--sort")
+		if err != nil {
+			return err
+		}
+		order := sortManual
+		if sortStr != "" {
+			if order, err = parseTimerSort(sortStr); err != nil {
+				return err
+			}
+		}
+
+		filter := ""
+		if len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			filter = args[0]
+		}
+		if count {
+			// Just the number, for status bars and scripts
+			fmt.Println(len(getFilteredTimers(timers, filter)))
+			break
+		}
+		if porcelain {
+			if asJSON || follow || compact {
+				return fmt.Errorf("--porcelain can't be combined with --json, --watch or --compact")
+			}
+			printPorcelain(timers, filter, order, reverse)
+			break
+		}
+		if follow {
+			return followList(filter, order, reverse, compact, asJSON)
+		}
+		if asJSON {
+			return printTimersJSON(timers, filter, order, reverse)
+		}
+		listTimers(timers, filter, order, reverse, compact)
+
+	case "total":
 		filter := ""
 		if len(args) > 0 && strings.HasPrefix(args[0], "--") {
 			filter = args[0]
 		}
-		listTimers(timers, filter)
+		fmt.Println(formatDuration(totalRemaining(getFilteredTimers(timers, filter), nowFunc())))
+
+	case "next":
+		asJSON, _ := takeBoolFlag(args, "--json")
+		return printNextTimer(timers, asJSON)
 
 	case "pause":
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
 		// Check for --all flag
-		if len(args) > 0 && args[0] == "--all" {
+		if target == "" && len(args) > 0 && args[0] == "--all" {
 			count := 0
-			now := time.Now()
+			now := nowFunc()
 			for i := range timers {
-				if !timers[i].Paused && timers[i].End.After(now) {
-					timers[i].Remaining = time.Until(timers[i].End)
-					timers[i].Paused = true
+				if timers[i].pause(now) {
 					count++
 				}
 			}
 			if count > 0 {
 				dirty = true
 			}
-			fmt.Printf("Paused %d timer(s)\n", count)
+			infof("Paused %d timer(s)\n", count)
 		} else {
-			filter, _, idx := parseFilterAndIndex(args)
-			actualIdx, err := resolveIndex(timers, filter, idx)
+			actualIdx, err := resolveTarget(timers, target, args)
 			if err != nil {
 				return err
 			}
 			if actualIdx >= 0 && len(timers) > 0 {
 				t := &timers[actualIdx]
 				if !t.Paused {
-					if t.End.After(time.Now()) {
-						t.Remaining = time.Until(t.End)
-						t.Paused = true
+					if t.pause(nowFunc()) {
 						dirty = true
-						fmt.Printf("Paused timer \"%s\"\n", t.Name)
+						infof("Paused timer \"%s\"\n", t.Name)
 					} else {
 						return fmt.Errorf("cannot pause: timer already done")
 					}
 				} else {
-					fmt.Printf("Timer \"%s\" is already paused\n", t.Name)
+					infof("Timer \"%s\" is already paused\n", t.Name)
 				}
 			}
 		}
 
 	case "resume":
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
 		// Check for --all flag
-		if len(args) > 0 && args[0] == "--all" {
+		if target == "" && len(args) > 0 && args[0] == "--all" {
 			count := 0
 			for i := range timers {
-				if timers[i].Paused && timers[i].Remaining > 0 {
-					timers[i].End = time.Now().Add(timers[i].Remaining)
-					timers[i].Paused = false
+				if timers[i].resume(nowFunc()) {
 					count++
 				}
 			}
 			if count > 0 {
 				dirty = true
 			}
-			fmt.Printf("Resumed %d timer(s)\n", count)
+			infof("Resumed %d timer(s)\n", count)
 		} else {
-			filter, _, idx := parseFilterAndIndex(args)
-			actualIdx, err := resolveIndex(timers, filter, idx)
+			actualIdx, err := resolveTarget(timers, target, args)
 			if err != nil {
 				return err
 			}
 			if actualIdx >= 0 && len(timers) > 0 {
 				t := &timers[actualIdx]
 				if t.Paused {
-					if t.Remaining > 0 {
-						t.End = time.Now().Add(t.Remaining)
-						t.Paused = false
+					if t.resume(nowFunc()) {
 						dirty = true
-						fmt.Printf("Resumed timer \"%s\"\n", t.Name)
+						infof("Resumed timer \"%s\"\n", t.Name)
 					} else {
 						return fmt.Errorf("cannot resume: no remaining time")
 					}
 				} else {
-					fmt.Printf("Timer \"%s\" is already active\n", t.Name)
+					infof("Timer \"%s\" is already active\n", t.Name)
 				}
 			}
 		}
 
 	case "delete":
-		// Check for --done or --all flags
-		if len(args) > 0 && args[0] == "--done" {
-			now := time.Now()
+		// --dry-run lists what would be deleted and exits without saving
+		dryRun, args := takeBoolFlag(args, "--dry-run")
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			switch {
+			case target == "" && len(args) > 0 && (args[0] == "--done" || args[0] == "--all"):
+				filter := args[0]
+				if filter == "--all" {
+					filter = ""
+				}
+				listTimers(timers, filter, sortManual, false, false)
+			default:
+				actualIdx, err := resolveTarget(timers, target, args)
+				if err != nil {
+					return err
+				}
+				// Show the timer under the index that was typed, not as [1]
+				t := timers[actualIdx]
+				printTimers([]Timer{t}, map[string]int{t.ID: actualIdx + 1}, sortManual, false, false)
+			}
+			fmt.Println("Dry run: nothing was deleted")
+			return nil
+		}
+
+		// Check for --done or --all flags (not combined with --name)
+		bulk := ""
+		if target == "" && len(args) > 0 {
+			bulk = args[0]
+		}
+		if bulk == "--done" {
+			now := nowFunc()
 			newTimers := make([]Timer, 0, len(timers))
 			count := 0
 			for _, t := range timers {
@@ -312,8 +1162,8 @@ func executeCLICommand(cmd string, args []string) error {
 				dirty = true
 			}
 			timers = newTimers
-			fmt.Printf("Deleted %d completed timer(s)\n", count)
-		} else if len(args) > 0 && args[0] == "--all" {
+			infof("Deleted %d completed timer(s)\n", count)
+		} else if bulk == "--all" {
 			// Require confirmation for delete --all
 			fmt.Print("Delete all timers? [y/N]: ")
 			var response string
@@ -322,13 +1172,12 @@ func executeCLICommand(cmd string, args []string) error {
 				count := len(timers)
 				timers = []Timer{}
 				dirty = true
-				fmt.Printf("Deleted %d timer(s)\n", count)
+				infof("Deleted %d timer(s)\n", count)
 			} else {
 				fmt.Println("Cancelled")
 			}
 		} else {
-			filter, _, idx := parseFilterAndIndex(args)
-			actualIdx, err := resolveIndex(timers, filter, idx)
+			actualIdx, err := resolveTarget(timers, target, args)
 			if err != nil {
 				return err
 			}
@@ -336,102 +1185,198 @@ func executeCLICommand(cmd string, args []string) error {
 				deletedName := timers[actualIdx].Name
 				timers = append(timers[:actualIdx], timers[actualIdx+1:]...)
 				dirty = true
-				fmt.Printf("Deleted timer \"%s\"\n", deletedName)
+				infof("Deleted timer \"%s\"\n", deletedName)
 			}
 		}
 
 	case "restart":
-		// Check for --all, --active, or --paused flags
-		if len(args) > 0 && args[0] == "--all" {
+		// --keep-paused re-arms paused timers without starting them
+		keepPaused, args := takeBoolFlag(args, "--keep-paused")
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
+
+		// Check for --all, --active, or --paused flags (not combined with --name)
+		bulk := ""
+		if target == "" && len(args) > 0 {
+			bulk = args[0]
+		}
+		if bulk == "--all" {
 			count := 0
 			for i := range timers {
 				if timers[i].Duration > 0 {
-					timers[i].End = time.Now().Add(timers[i].Duration)
-					timers[i].Paused = false
-					timers[i].Remaining = 0
+					timers[i].restart(nowFunc(), keepPaused)
 					count++
 				}
 			}
 			if count > 0 {
 				dirty = true
 			}
-			fmt.Printf("Restarted %d timer(s)\n", count)
-		} else if len(args) > 0 && args[0] == "--active" {
-			now := time.Now()
+			infof("Restarted %d timer(s)\n", count)
+		} else if bulk == "--active" {
+			now := nowFunc()
 			count := 0
 			for i := range timers {
 				if !timers[i].Paused && timers[i].End.After(now) && timers[i].Duration > 0 {
-					timers[i].End = time.Now().Add(timers[i].Duration)
-					timers[i].Paused = false
-					timers[i].Remaining = 0
+					timers[i].restart(nowFunc(), keepPaused)
 					count++
 				}
 			}
 			if count > 0 {
 				dirty = true
 			}
-			fmt.Printf("Restarted %d active timer(s)\n", count)
-		} else if len(args) > 0 && args[0] == "--paused" {
+			infof("Restarted %d active timer(s)\n", count)
+		} else if bulk == "--paused" {
 			count := 0
 			for i := range timers {
 				if timers[i].Paused && timers[i].Duration > 0 {
-					timers[i].End = time.Now().Add(timers[i].Duration)
-					timers[i].Paused = false
-					timers[i].Remaining = 0
+					timers[i].restart(nowFunc(), keepPaused)
 					count++
 				}
 			}
 			if count > 0 {
 				dirty = true
 			}
-			fmt.Printf("Restarted %d paused timer(s)\n", count)
+			infof("Restarted %d paused timer(s)\n", count)
 		} else {
-			filter, _, idx := parseFilterAndIndex(args)
-			actualIdx, err := resolveIndex(timers, filter, idx)
+			actualIdx, err := resolveTarget(timers, target, args)
 			if err != nil {
 				return err
 			}
 			if actualIdx >= 0 && len(timers) > 0 && timers[actualIdx].Duration > 0 {
 				t := &timers[actualIdx]
-				t.End = time.Now().Add(t.Duration)
-				t.Paused = false
-				t.Remaining = 0
+				t.restart(nowFunc(), keepPaused)
 				dirty = true
-				fmt.Printf("Restarted timer \"%s\"\n", t.Name)
+				if t.Paused {
+					infof("Restarted timer \"%s\" (kept paused)\n", t.Name)
+				} else {
+					infof("Restarted timer \"%s\"\n", t.Name)
+				}
 			}
 		}
 
+	case "reset":
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
+		if target == "" && len(args) == 0 {
+			fmt.Println("Usage: go-countdown reset [--filter] <index>")
+			return nil
+		}
+		actualIdx, err := resolveTarget(timers, target, args)
+		if err != nil {
+			return err
+		}
+		t := &timers[actualIdx]
+		if t.Duration <= 0 {
+			return fmt.Errorf("cannot reset: timer has no duration")
+		}
+		t.reset(nowFunc())
+		dirty = true
+		infof("Reset timer \"%s\" to %s (paused)\n", t.Name, formatDuration(t.Duration))
+
 	case "edit":
-		if len(args) < 3 {
-			fmt.Println("Usage: go-countdown edit [--filter] <index> <name> <duration>")
+		// --note "" clears the note, so presence matters rather than the value
+		notes, args, err := takeFlagValues(args, "--note")
+		if err != nil {
+			return err
+		}
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
+		adjust, args := takeBoolFlag(args, "--adjust")
+		addStr, args, err := takeFlag(args, "--add")
+		if err != nil {
+			return err
+		}
+		subStr, args, err := takeFlag(args, "--sub")
+		if err != nil {
+			return err
+		}
+		if addStr != "" || subStr != "" {
+			// Shift the time left on every timer matching the filter
+			if addStr != "" && subStr != "" {
+				return fmt.Errorf("use only one of --add and --sub")
+			}
+			if target != "" || len(notes) > 0 || adjust || len(args) > 1 || (len(args) == 1 && !strings.HasPrefix(args[0], "--")) {
+				return fmt.Errorf("--add and --sub only take a filter, e.g. edit --active --add 10m")
+			}
+			delta, err := parseDuration(addStr + subStr)
+			if err != nil {
+				return fmt.Errorf("invalid duration: %w", err)
+			}
+			if subStr != "" {
+				delta = -delta
+			}
+			filter := ""
+			if len(args) == 1 {
+				filter = args[0]
+			}
+
+			matching := make(map[string]bool)
+			for _, t := range getFilteredTimers(timers, filter) {
+				matching[t.ID] = true
+			}
+			now := nowFunc()
+			count := 0
+			for i := range timers {
+				// Never below 1s left; done timers only take added time
+				if matching[timers[i].ID] && timers[i].adjustRemaining(now, delta) {
+					count++
+				}
+			}
+			if count > 0 {
+				dirty = true
+			}
+			infof("Adjusted %d timer(s)\n", count)
+			break
+		}
+
+		// The timer is picked by --name or by [filter] <index>; new values follow
+		var filter, indexStr, name, durationStr string
+		rest := args
+		if target == "" {
+			if len(rest) > 0 && strings.HasPrefix(rest[0], "--") {
+				filter, rest = rest[0], rest[1:]
+			}
+			if len(rest) > 0 {
+				indexStr, rest = rest[0], rest[1:]
+			}
+		}
+		if len(rest) > 0 {
+			name = rest[0]
+		}
+		if len(rest) > 1 {
+			durationStr = rest[1]
+		}
+		if (target == "" && indexStr == "") || (len(rest) == 0 && len(notes) == 0) {
+			fmt.Println("Usage: go-countdown edit [--filter] <index> <name> [duration] [--adjust] [--note <text>]")
+			fmt.Println("       go-countdown edit [--filter] <index> --note <text>")
+			fmt.Println("       go-countdown edit --name <current name> <name> [duration]")
+			fmt.Println("       go-countdown edit [--filter] --add|--sub <duration>")
 			fmt.Println("\nExamples:")
 			fmt.Println("  go-countdown edit 1 \"New Name\" 10m")
 			fmt.Println("  go-countdown edit --active 1 \"New Name\" 10m")
+			fmt.Println("  go-countdown edit 1 --note \"https://example.com/agenda\"")
+			fmt.Println("  go-countdown edit --name \"Meeting\" \"Standup\" 15m")
+			fmt.Println("  go-countdown edit 1 \"Focus\" 50m --adjust   # keep progress, move the end")
+			fmt.Println("  go-countdown edit --active --add 10m       # 10 more minutes on every running timer")
 			return nil
 		}
 
-		var filter, indexStr, name, durationStr string
-		if strings.HasPrefix(args[0], "--") {
-			filter = args[0]
-			indexStr = args[1]
-			name = args[2]
-			if len(args) > 3 {
-				durationStr = args[3]
-			}
+		var actualIdx int
+		if target != "" {
+			actualIdx, err = resolveName(timers, target)
 		} else {
-			indexStr = args[0]
-			name = args[1]
-			if len(args) > 2 {
-				durationStr = args[2]
+			idx, convErr := strconv.Atoi(indexStr)
+			if convErr != nil || idx < 1 {
+				return fmt.Errorf("invalid index: %s", indexStr)
 			}
+			actualIdx, err = resolveIndex(timers, filter, idx)
 		}
-
-		idx, err := strconv.Atoi(indexStr)
-		if err != nil || idx < 1 {
-			return fmt.Errorf("invalid index: %s", indexStr)
-		}
-
-		actualIdx, err := resolveIndex(timers, filter, idx)
 		if err != nil {
 			return err
 		}
@@ -445,27 +1390,331 @@ func executeCLICommand(cmd string, args []string) error {
 				t.Name = name
 			}
 
+			if len(notes) > 0 {
+				t.Note = strings.TrimSpace(notes[len(notes)-1])
+			}
+
 			// Update duration if provided
 			if durationStr != "" {
 				d, err := parseDuration(durationStr)
 				if err != nil {
 					return fmt.Errorf("invalid duration: %w", err)
 				}
-				t.Duration = d
-				t.End = time.Now().Add(d)
-				t.Paused = false
-				t.Remaining = 0
+				if adjust {
+					// Keep the elapsed progress; only the end moves
+					t.setDuration(nowFunc(), d)
+				} else {
+					t.Duration = d
+					t.restart(nowFunc(), false)
+				}
 			}
 
 			dirty = true
-			fmt.Printf("Edited timer: \"%s\" -> \"%s\"\n", oldName, t.Name)
+			infof("Edited timer: \"%s\" -> \"%s\"\n", oldName, t.Name)
 		}
 
+	case "duplicate":
+		filter, indexStr, idx := parseFilterAndIndex(args)
+		if indexStr == "" {
+			fmt.Println("Usage: go-countdown duplicate [--filter] <index>")
+			return nil
+		}
+		if idx < 1 {
+			return fmt.Errorf("invalid index: %s", indexStr)
+		}
+		actualIdx, err := resolveIndex(timers, filter, idx)
+		if err != nil {
+			return err
+		}
+
+		c := timers[actualIdx].duplicate(nowFunc())
+		timers = append(timers[:actualIdx+1], append([]Timer{c}, timers[actualIdx+1:]...)...)
+		dirty = true
+		infof("Duplicated timer \"%s\" as \"%s\"\n", timers[actualIdx].Name, c.Name)
+
+	case "pin", "unpin":
+		target, args, err := takeFlag(args, "--name")
+		if err != nil {
+			return err
+		}
+		if target == "" && len(args) == 0 {
+			fmt.Printf("Usage: go-countdown %s [--filter] <index>\n", cmd)
+			return nil
+		}
+		actualIdx, err := resolveTarget(timers, target, args)
+		if err != nil {
+			return err
+		}
+		t := &timers[actualIdx]
+		pin := cmd == "pin"
+		if t.Pinned == pin {
+			if pin {
+				infof("Timer \"%s\" is already pinned\n", t.Name)
+			} else {
+				infof("Timer \"%s\" is not pinned\n", t.Name)
+			}
+			break
+		}
+		t.Pinned = pin
+		dirty = true
+		if pin {
+			infof("Pinned timer \"%s\"\n", t.Name)
+		} else {
+			infof("Unpinned timer \"%s\"\n", t.Name)
+		}
+
+	case "move":
+		if len(args) < 2 {
+			fmt.Println("Usage: go-countdown move <from> <to>")
+			return nil
+		}
+		from, err := strconv.Atoi(args[0])
+		if err != nil || from < 1 || from > len(timers) {
+			return fmt.Errorf("invalid index: %s", args[0])
+		}
+		to, err := strconv.Atoi(args[1])
+		if err != nil || to < 1 {
+			return fmt.Errorf("invalid position: %s", args[1])
+		}
+		to = min(to, len(timers))
+
+		t := timers[from-1]
+		timers = append(timers[:from-1], timers[from:]...)
+		timers = append(timers[:to-1], append([]Timer{t}, timers[to-1:]...)...)
+		dirty = true
+		infof("Moved timer \"%s\" to position %d\n", t.Name, to)
+
+	case "pomodoro":
+		workStr, args, err := takeFlag(args, "--work")
+		if err != nil {
+			return err
+		}
+		breakStr, args, err := takeFlag(args, "--break")
+		if err != nil {
+			return err
+		}
+		roundsStr, _, err := takeFlag(args, "--rounds")
+		if err != nil {
+			return err
+		}
+
+		work, brk, rounds := 25*time.Minute, 5*time.Minute, 4
+		if workStr != "" {
+			if work, err = parseDuration(workStr); err != nil {
+				return fmt.Errorf("invalid --work duration: %w", err)
+			}
+		}
+		if breakStr != "" {
+			if brk, err = parseDuration(breakStr); err != nil {
+				return fmt.Errorf("invalid --break duration: %w", err)
+			}
+		}
+		if roundsStr != "" {
+			if rounds, err = strconv.Atoi(roundsStr); err != nil || rounds < 1 {
+				return fmt.Errorf("invalid --rounds: %s", roundsStr)
+			}
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			cfg = defaultConfig()
+		}
+		session := newPomodoro(work, brk, rounds, cfg.newTimerTags(), nowFunc())
+		timers = append(timers, session...)
+		dirty = true
+		infof("Added %d pomodoro timers (%d rounds of %s work, %s breaks); \"Work 1\" is running\n",
+			len(session), rounds, formatDuration(work), formatDuration(brk))
+
+	case "snooze":
+		filter, indexStr, idx := parseFilterAndIndex(args)
+		if indexStr == "" {
+			fmt.Println("Usage: go-countdown snooze [--filter] <index> [duration]")
+			return nil
+		}
+		if idx < 1 {
+			return fmt.Errorf("invalid index: %s", indexStr)
+		}
+		actualIdx, err := resolveIndex(timers, filter, idx)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			cfg = defaultConfig()
+		}
+		d := cfg.snoozeDuration()
+		// The optional duration follows the index
+		durationArgs := args[1:]
+		if filter != "" {
+			durationArgs = args[2:]
+		}
+		if len(durationArgs) > 0 {
+			d, err = parseDuration(durationArgs[0])
+			if err != nil {
+				return fmt.Errorf("invalid duration: %w", err)
+			}
+		}
+
+		t := &timers[actualIdx]
+		t.snooze(nowFunc(), d)
+		dirty = true
+		infof("Snoozed timer \"%s\" by %s (%s left)\n", t.Name, formatDuration(d), formatDuration(t.remainingAt(nowFunc())))
+
+	case "billing":
+		filter := ""
+		if len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			filter = args[0]
+		}
+		printBilling(timers, filter)
+
+	case "export":
+		ics, args := takeBoolFlag(args, "--ics")
+		asCSV, args := takeBoolFlag(args, "--csv")
+		if ics == asCSV {
+			fmt.Println("Usage: go-countdown export [--filter] --ics|--csv")
+			return nil
+		}
+		filter := ""
+		if len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			filter = args[0]
+		}
+		if asCSV {
+			return writeCSV(os.Stdout, getFilteredTimers(timers, filter), nowFunc())
+		}
+		return writeICS(os.Stdout, getFilteredTimers(timers, filter), nowFunc())
+
+	case "import":
+		replace, args := takeBoolFlag(args, "--replace")
+		if len(args) < 1 {
+			fmt.Println("Usage: go-countdown import <file.json> [--replace]")
+			return nil
+		}
+		imported, err := readSaveFile(args[0])
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", args[0], err)
+		}
+		migrateSave(&imported)
+
+		if replace {
+			timers = nil
+		}
+		taken := make(map[string]bool)
+		for _, t := range timers {
+			taken[t.Name] = true
+		}
+
+		count, skipped := 0, 0
+		for _, t := range imported.Timers {
+			if t.Duration <= 0 {
+				infof("Skipped \"%s\": duration must be positive\n", t.Name)
+				skipped++
+				continue
+			}
+			// Fresh IDs keep a file imported twice from clashing with itself
+			t.ID = newTimerID()
+			if name := uniqueName(t.Name, taken); name != t.Name {
+				infof("Renamed \"%s\" to \"%s\"\n", t.Name, name)
+				t.Name = name
+			}
+			taken[t.Name] = true
+			timers = append(timers, t)
+			count++
+		}
+		if count > 0 || replace {
+			dirty = true
+		}
+		infof("Imported %d timer(s), skipped %d\n", count, skipped)
+
+	case "import-at":
+		file := ""
+		if len(args) > 0 {
+			file = args[0]
+		}
+		imported, skipped, err := importAtJobs(file, nowFunc())
+		if err != nil {
+			return err
+		}
+		now := nowFunc()
+		for _, t := range imported {
+			infof("Imported \"%s\" (%s)\n", t.Name, formatDuration(t.End.Sub(now)))
+		}
+		for _, s := range skipped {
+			infof("Skipped %s\n", s)
+		}
+		if len(imported) > 0 {
+			timers = append(timers, imported...)
+			dirty = true
+		}
+		infof("Imported %d job(s), skipped %d\n", len(imported), len(skipped))
+
+	case "tray":
+		return runTray()
+
+	case "metrics":
+		filter := ""
+		if len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			filter = args[0]
+		}
+		return writeMetrics(os.Stdout, getFilteredTimers(timers, filter), nowFunc())
+
+	case "serve":
+		port, args, err := takeFlag(args, "--port")
+		if err != nil {
+			return err
+		}
+		host, _, err := takeFlag(args, "--host")
+		if err != nil {
+			return err
+		}
+		if port == "" {
+			port = "8080"
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("invalid port: %s", port)
+		}
+		if host == "" {
+			// Local only unless asked; the API has no authentication
+			host = "127.0.0.1"
+		}
+		return runServer(host, port)
+
+	case "profiles":
+		profiles, err := listProfiles()
+		if err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			fmt.Println("No profiles found.")
+			break
+		}
+		for _, p := range profiles {
+			// Mark the profile this command is using
+			marker := " "
+			if path, _ := profileFile(p); path == saveFile {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, p)
+		}
+
+	case "version":
+		// Normally answered in main; reached through --auto-correct
+		fmt.Println("go-countdown", version)
+
 	case "help", "-h", "--help":
 		printUsage()
 
 	default:
-		return fmt.Errorf("unknown command: %s", cmd)
+		suggestions := suggestCommands(cmd)
+		if len(suggestions) == 0 {
+			return fmt.Errorf("unknown command: %s", cmd)
+		}
+		// --auto-correct runs the suggestion when there is exactly one
+		if autoCorrect, rest := takeBoolFlag(args, "--auto-correct"); autoCorrect && len(suggestions) == 1 {
+			fmt.Fprintf(os.Stderr, "unknown command %s, running '%s'\n", cmd, suggestions[0])
+			return executeCLICommand(suggestions[0], rest)
+		}
+		return fmt.Errorf("unknown command: %s (did you mean '%s'?)", cmd, strings.Join(suggestions, "' or '"))
 	}
 
 	// Save if any changes were made
@@ -475,5 +1724,12 @@ func executeCLICommand(cmd string, args []string) error {
 		}
 	}
 
+	// Hand over to the TUI with the timer selected
+	if watchTimer != nil {
+		m := initialModel()
+		m.selectTimer(*watchTimer)
+		return runTUI(m)
+	}
+
 	return nil
 }
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddLongDurationPromptUsesListLayouts(t *testing.T) {
+	useTempFiles(t)
+	writeConfig(t, `{"confirmLongDurations": true, "displayTimezone": "UTC"}`)
+	withStdin(t, "n\n")
+
+	out := captureStdout(t, func() {
+		if err := executeCLICommand("add", []string{"trip", "40d"}); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.Contains(out, "This will end on Apr 19 (5w 5d)") {
+		t.Errorf("prompt doesn't spell out the month like list:\n%s", out)
+	}
+	if !strings.Contains(out, "Cancelled") {
+		t.Errorf("answering n didn't cancel:\n%s", out)
+	}
+	if timers, _ := loadTimers(); len(timers) != 0 {
+		t.Errorf("cancelled add saved %d timers", len(timers))
+	}
+}
+
+func TestDeleteDryRunShowsTypedIndex(t *testing.T) {
+	useTempFiles(t)
+	timers := []Timer{
+		{ID: "a", Name: "first", Duration: time.Minute, End: testNow.Add(time.Minute)},
+		{ID: "b", Name: "second", Duration: time.Minute, End: testNow.Add(time.Minute)},
+		{ID: "c", Name: "third", Duration: time.Minute, End: testNow.Add(time.Minute)},
+	}
+	if err := saveTimers(timers); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := executeCLICommand("delete", []string{"--dry-run", "3"}); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.Contains(out, "[3] [active] third") {
+		t.Errorf("dry run doesn't list the timer as [3]:\n%s", out)
+	}
+	if strings.Contains(out, "[1]") {
+		t.Errorf("dry run relabelled the timer as [1]:\n%s", out)
+	}
+	if saved, _ := loadTimers(); len(saved) != 3 {
+		t.Errorf("dry run left %d timers, want 3", len(saved))
+	}
+}
+
+func TestTakeGlobalFlags(t *testing.T) {
+	useTempFiles(t)
+	t.Setenv("NO_COLOR", "") // leave colors alone for the other tests
+	args, err := takeGlobalFlags([]string{"--data-file", "/tmp/work.json", "--config=/tmp/work-config.json", "list", "--done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"list", "--done"}; !slices.Equal(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if saveFile != "/tmp/work.json" {
+		t.Errorf("saveFile = %q, want /tmp/work.json", saveFile)
+	}
+	if configFile != "/tmp/work-config.json" {
+		t.Errorf("configFile = %q, want /tmp/work-config.json", configFile)
+	}
+}
+
+func TestGlobalFlagsOnlyBeforeCommand(t *testing.T) {
+	useTempFiles(t)
+	t.Setenv("NO_COLOR", "")
+	t.Cleanup(func() { quietOutput = false })
+	args, err := takeGlobalFlags([]string{"add", "x", "5m", "--note", "-q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quietOutput {
+		t.Error("-q after the command turned on quiet output")
+	}
+	captureStdout(t, func() {
+		if err := executeCLICommand(args[0], args[1:]); err != nil {
+			t.Error(err)
+		}
+	})
+	timers, err := loadTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timers) != 1 || timers[0].Note != "-q" {
+		t.Errorf("timers = %+v, want one with the note \"-q\"", timers)
+	}
+}
+
+func TestTakeGlobalFlagsErrors(t *testing.T) {
+	useTempFiles(t)
+	for _, args := range [][]string{
+		{"--data-file"},
+		{"--config"},
+		{"--data-file", "a.json", "--profile", "work", "list"},
+	} {
+		if _, err := takeGlobalFlags(args); err == nil {
+			t.Errorf("takeGlobalFlags(%q) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestDefaultSaveFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	t.Setenv(dataFileEnv, "")
+	if got, want := defaultSaveFile(), filepath.Join("/tmp/xdg", "go-countdown", "timers.json"); got != want {
+		t.Errorf("defaultSaveFile() = %q, want %q", got, want)
+	}
+	t.Setenv(dataFileEnv, "/tmp/env.json")
+	if got := defaultSaveFile(); got != "/tmp/env.json" {
+		t.Errorf("with %s set, defaultSaveFile() = %q, want /tmp/env.json", dataFileEnv, got)
+	}
+}
+
+func TestAddAtUsesClock(t *testing.T) {
+	useTempFiles(t)
+	if err := executeCLICommand("add", []string{"standup", "--at", "tomorrow 9am"}); err != nil {
+		t.Fatal(err)
+	}
+	timers, err := loadTimers()
+	if err != nil || len(timers) != 1 {
+		t.Fatalf("loadTimers() = %v, %v; want one timer", timers, err)
+	}
+	if want := time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC); !timers[0].End.Equal(want) {
+		t.Errorf("End = %v, want %v", timers[0].End, want)
+	}
+}
+
+func TestCommandsReadNowFunc(t *testing.T) {
+	useTempFiles(t)
+	if err := executeCLICommand("add", []string{"tea", "3m"}); err != nil {
+		t.Fatal(err)
+	}
+	list := func() string {
+		return captureStdout(t, func() {
+			if err := executeCLICommand("list", nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	if out := list(); !strings.Contains(out, "[active] tea") || !strings.Contains(out, "3m") {
+		t.Errorf("new timer isn't active with 3m left:\n%s", out)
+	}
+
+	// Pausing a minute in keeps exactly the 2m left, however long it stays paused
+	setClock(testNow.Add(time.Minute))
+	if err := executeCLICommand("pause", []string{"1"}); err != nil {
+		t.Fatal(err)
+	}
+	setClock(testNow.Add(time.Hour))
+	if err := executeCLICommand("resume", []string{"1"}); err != nil {
+		t.Fatal(err)
+	}
+	timers, _ := loadTimers()
+	if want := testNow.Add(time.Hour + 2*time.Minute); len(timers) != 1 || !timers[0].End.Equal(want) {
+		t.Fatalf("resumed timer ends %v, want %v", timers, want)
+	}
+
+	setClock(testNow.Add(time.Hour + 2*time.Minute))
+	if out := list(); !strings.Contains(out, "[done] tea") {
+		t.Errorf("timer isn't done once the clock reaches its end:\n%s", out)
+	}
+}
+
+func TestListShowsDoneAgo(t *testing.T) {
+	useTempFiles(t)
+	timers := []Timer{{ID: "a", Name: "tea", Duration: 5 * time.Minute, Started: testNow.Add(-390 * time.Second), End: testNow.Add(-90 * time.Second)}}
+	if err := saveTimers(timers); err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(t, func() {
+		if err := executeCLICommand("list", nil); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.Contains(out, "(done 1m 30s ago)") {
+		t.Errorf("list doesn't show how long ago the timer ended:\n%s", out)
+	}
+}
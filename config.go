@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -13,7 +14,7 @@ import (
 type DurationUnit string
 
 const (
-	UnitSmart   DurationUnit = "smart"   // Auto-detect based on current value
+	UnitSmart   DurationUnit = "smart" // Auto-detect based on current value
 	UnitSeconds DurationUnit = "seconds"
 	UnitMinutes DurationUnit = "minutes"
 	UnitHours   DurationUnit = "hours"
@@ -21,19 +22,114 @@ const (
 
 type DurationAdjustConfig struct {
 	Unit               DurationUnit `json:"unit"`
-	IncrementStep      int          `json:"incrementStep"`      // e.g., 1, 5, 10
-	ShiftIncrementStep int          `json:"shiftIncrementStep"` // for larger jumps
+	IncrementStep      int          `json:"incrementStep"`       // e.g., 1, 5, 10
+	ShiftIncrementStep int          `json:"shiftIncrementStep"`  // for larger jumps
+	AllExcludesDone    bool         `json:"allExcludesDone"`     // "All" filter hides done timers
+	DoneLast           bool         `json:"doneLast,omitempty"`  // done timers sort after the rest
+	Latitude           *float64     `json:"latitude,omitempty"`  // for sunrise/sunset timers
+	Longitude          *float64     `json:"longitude,omitempty"` // east positive
+
+	// Ask for confirmation before adding timers longer than LongDurationDays
+	ConfirmLongDurations bool `json:"confirmLongDurations"`
+	LongDurationDays     int  `json:"longDurationDays"`
+
+	DefaultTags []string `json:"defaultTags,omitempty"` // applied to every new timer
+
+	// Table columns to show, in order (status, name, tag, remaining, progress, end, note); empty shows all
+	Columns []string `json:"columns,omitempty"`
+
+	SnoozeStep string `json:"snoozeStep"` // time added by snooze, e.g. "5m"
+
+	// Pre-filled in the add form and used by "add <name>" without a duration, e.g. "25m"
+	DefaultDuration string `json:"defaultDuration,omitempty"`
+
+	// Done timers are deleted once finished for longer than this, e.g. "1d"; empty or "0" keeps them
+	AutoDeleteDoneAfter string `json:"autoDeleteDoneAfter,omitempty"`
+
+	// Keys for TUI actions, e.g. {"up": ["up", "c"]}; actions not listed keep their defaults
+	Keybindings map[string][]string `json:"keybindings,omitempty"`
+
+	// Delete, restart and bulk confirmations left open this long are cancelled, e.g. "30s"; empty or "0" never
+	ConfirmTimeout string `json:"confirmTimeout,omitempty"`
+
+	// Custom events for "add --event": name -> "MM-DD" (annual) or "YYYY-MM-DD" (one-off)
+	Events map[string]string `json:"events,omitempty"`
+
+	DisableNotifications bool `json:"disableNotifications"` // no desktop notification when a timer completes
+
+	// Audible cue when a timer completes: the terminal bell, or SoundFile if set
+	Sound     bool   `json:"sound"`
+	SoundFile string `json:"soundFile,omitempty"`
+
+	// Time zone end times are shown in: an IANA name like "Europe/Berlin", "utc" or "local" (default)
+	DisplayTimezone string         `json:"displayTimezone,omitempty"`
+	location        *time.Location // loaded from DisplayTimezone
+
+	// Go time layouts for end times ending today, later this month, later
+	// this year and in another year, e.g. "01/02 3:04PM" for month-first
+	// dates. Empty keeps the built-in layouts, which differ between the TUI
+	// and list.
+	SameDayFormat   string `json:"sameDayFormat,omitempty"`
+	SameMonthFormat string `json:"sameMonthFormat,omitempty"`
+	SameYearFormat  string `json:"sameYearFormat,omitempty"`
+	FullDateFormat  string `json:"fullDateFormat,omitempty"`
+
+	// Quiet hours ("HH:MM"): running timers pause at the start and resume at the end
+	QuietHoursStart string `json:"quietHoursStart,omitempty"`
+	QuietHoursEnd   string `json:"quietHoursEnd,omitempty"`
+}
+
+// inQuietHours reports whether now falls inside the configured quiet hours,
+// which may span midnight (e.g. 18:00-09:00)
+func (c DurationAdjustConfig) inQuietHours(now time.Time) bool {
+	if c.QuietHoursStart == "" || c.QuietHoursEnd == "" {
+		return false
+	}
+	startH, startM, err := parseTimeOfDay(c.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	endH, endM, err := parseTimeOfDay(c.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	start := startH*60 + startM
+	end := endH*60 + endM
+	minute := now.Hour()*60 + now.Minute()
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// sessionTagEnv holds comma-separated tags added to every new timer in this session
+const sessionTagEnv = "GO_COUNTDOWN_SESSION_TAG"
+
+// newTimerTags returns the tags applied to every new timer: the configured
+// defaults followed by any session tags from the environment
+func (c DurationAdjustConfig) newTimerTags() []string {
+	return mergeTags(c.DefaultTags, strings.Split(os.Getenv(sessionTagEnv), ","))
 }
 
 var configFile string
 
 func init() {
+	configFile = filepath.Join(appDir(), "config.json")
+}
+
+// appDir returns the directory holding the config and timers files:
+// $XDG_CONFIG_HOME/go-countdown, falling back to ~/.config/go-countdown.
+// It is empty (the working directory) when neither can be determined.
+func appDir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "go-countdown")
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		configFile = "config.json"
-	} else {
-		configFile = filepath.Join(homeDir, ".config", "go-countdown", "config.json")
+		return ""
 	}
+	return filepath.Join(homeDir, ".config", "go-countdown")
 }
 
 func defaultConfig() DurationAdjustConfig {
@@ -41,6 +137,8 @@ func defaultConfig() DurationAdjustConfig {
 		Unit:               UnitSmart,
 		IncrementStep:      1,
 		ShiftIncrementStep: 5,
+		LongDurationDays:   7,
+		SnoozeStep:         "5m",
 	}
 }
 
@@ -78,10 +176,171 @@ func loadConfig() (DurationAdjustConfig, error) {
 	if cfg.ShiftIncrementStep <= 0 {
 		cfg.ShiftIncrementStep = 5
 	}
+	if cfg.LongDurationDays <= 0 {
+		cfg.LongDurationDays = 7
+	}
+	if _, err := parseDuration(cfg.SnoozeStep); err != nil {
+		if cfg.SnoozeStep != "" {
+			log.Printf("warning: invalid snoozeStep %q, using 5m", cfg.SnoozeStep)
+		}
+		cfg.SnoozeStep = "5m"
+	}
+	if cfg.DefaultDuration != "" {
+		if _, err := parseDuration(cfg.DefaultDuration); err != nil {
+			log.Printf("warning: invalid defaultDuration %q, ignoring it", cfg.DefaultDuration)
+			cfg.DefaultDuration = ""
+		}
+	}
+	if cfg.AutoDeleteDoneAfter != "" && cfg.AutoDeleteDoneAfter != "0" {
+		if _, err := parseDuration(cfg.AutoDeleteDoneAfter); err != nil {
+			log.Printf("warning: invalid autoDeleteDoneAfter %q, keeping done timers", cfg.AutoDeleteDoneAfter)
+			cfg.AutoDeleteDoneAfter = ""
+		}
+	}
+	if cfg.ConfirmTimeout != "" && cfg.ConfirmTimeout != "0" {
+		if _, err := parseDuration(cfg.ConfirmTimeout); err != nil {
+			log.Printf("warning: invalid confirmTimeout %q, leaving confirmations open", cfg.ConfirmTimeout)
+			cfg.ConfirmTimeout = ""
+		}
+	}
+	if len(cfg.Keybindings) > 0 {
+		cfg.Keybindings = validKeybindings(cfg.Keybindings)
+	}
+	if err := validateColumns(cfg.Columns); err != nil {
+		log.Printf("warning: %v, showing all columns", err)
+		cfg.Columns = nil
+	}
+	if loc, err := loadDisplayLocation(cfg.DisplayTimezone); err != nil {
+		log.Printf("warning: invalid displayTimezone %q, using local time: %v", cfg.DisplayTimezone, err)
+		cfg.DisplayTimezone = ""
+	} else {
+		cfg.location = loc
+	}
+	for name, layout := range map[string]*string{
+		"sameDayFormat": &cfg.SameDayFormat, "sameMonthFormat": &cfg.SameMonthFormat,
+		"sameYearFormat": &cfg.SameYearFormat, "fullDateFormat": &cfg.FullDateFormat,
+	} {
+		if *layout != "" && !validTimeLayout(*layout) {
+			log.Printf("warning: invalid %s %q, using the default", name, *layout)
+			*layout = ""
+		}
+	}
+	if cfg.SoundFile != "" {
+		if _, err := os.Stat(cfg.SoundFile); err != nil {
+			log.Printf("warning: sound file %s not found, using the terminal bell", cfg.SoundFile)
+			cfg.SoundFile = ""
+		}
+	}
+	if cfg.QuietHoursStart != "" || cfg.QuietHoursEnd != "" {
+		_, _, errStart := parseTimeOfDay(cfg.QuietHoursStart)
+		_, _, errEnd := parseTimeOfDay(cfg.QuietHoursEnd)
+		if errStart != nil || errEnd != nil {
+			log.Printf("warning: invalid quiet hours %q-%q, ignoring", cfg.QuietHoursStart, cfg.QuietHoursEnd)
+			cfg.QuietHoursStart, cfg.QuietHoursEnd = "", ""
+		}
+	}
 
 	return cfg, nil
 }
 
+// validateColumns checks that the configured table columns are known and include the name
+func validateColumns(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	for _, name := range names {
+		if _, ok := tableColumn(name); !ok {
+			return fmt.Errorf("unknown column %q in columns", name)
+		}
+	}
+	if !slices.Contains(names, "name") {
+		return fmt.Errorf("columns must include \"name\"")
+	}
+	return nil
+}
+
+// loadDisplayLocation resolves a displayTimezone setting
+func loadDisplayLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// displayLocation returns the time zone end times are shown in
+func (c DurationAdjustConfig) displayLocation() *time.Location {
+	if c.location == nil {
+		return time.Local
+	}
+	return c.location
+}
+
+// validTimeLayout reports whether layout is a Go time layout, i.e. formatting
+// a time with it fills in at least one field instead of copying it verbatim
+func validTimeLayout(layout string) bool {
+	sample := time.Date(2031, time.November, 23, 21, 47, 58, 0, time.UTC)
+	return sample.Format(layout) != layout
+}
+
+// endTimeLayouts returns defaults with the configured end time formats in place
+func (c DurationAdjustConfig) endTimeLayouts(defaults endTimeLayouts) endTimeLayouts {
+	l := defaults
+	if c.SameDayFormat != "" {
+		l.sameDay = c.SameDayFormat
+	}
+	if c.SameMonthFormat != "" {
+		l.sameMonth = c.SameMonthFormat
+	}
+	if c.SameYearFormat != "" {
+		l.sameYear = c.SameYearFormat
+	}
+	if c.FullDateFormat != "" {
+		l.full = c.FullDateFormat
+	}
+	return l
+}
+
+// autoDeleteAfter returns how long done timers are kept, or 0 to keep them
+func (c DurationAdjustConfig) autoDeleteAfter() time.Duration {
+	if c.AutoDeleteDoneAfter == "" || c.AutoDeleteDoneAfter == "0" {
+		return 0
+	}
+	d, err := parseDuration(c.AutoDeleteDoneAfter)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// confirmTimeout returns how long a confirmation stays open, or 0 for no limit
+func (c DurationAdjustConfig) confirmTimeout() time.Duration {
+	if c.ConfirmTimeout == "" || c.ConfirmTimeout == "0" {
+		return 0
+	}
+	d, err := parseDuration(c.ConfirmTimeout)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// snoozeDuration returns the time a snooze adds
+func (c DurationAdjustConfig) snoozeDuration() time.Duration {
+	d, err := parseDuration(c.SnoozeStep)
+	if err != nil {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// longDurationThreshold returns the duration above which new timers need confirmation
+func (c DurationAdjustConfig) longDurationThreshold() time.Duration {
+	return time.Duration(c.LongDurationDays) * 24 * time.Hour
+}
+
 func saveConfig(cfg DurationAdjustConfig) error {
 	b, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -111,6 +370,10 @@ func getUnitMultiplier(unit DurationUnit, currentInput string) time.Duration {
 		switch largestUnit {
 		case "y":
 			return 365 * 24 * time.Hour
+		case "mo":
+			return 30 * 24 * time.Hour
+		case "w":
+			return 7 * 24 * time.Hour
 		case "d":
 			return 24 * time.Hour
 		case "h":
@@ -133,6 +396,8 @@ func detectLargestUnit(input string) string {
 		unitKey string
 	}{
 		{"y", "y"},
+		{"mo", "mo"},
+		{"w", "w"},
 		{"d", "d"},
 		{"h", "h"},
 		{"m", "m"},
@@ -149,16 +414,17 @@ func detectLargestUnit(input string) string {
 
 // containsUnit checks if the input contains the given unit suffix
 func containsUnit(input, suffix string) bool {
-	inputLower := input
-	for i := 0; i < len(inputLower); i++ {
+	for i := 1; i+len(suffix) <= len(input); i++ {
 		// Look for the suffix preceded by a digit
-		if inputLower[i] == suffix[0] && i > 0 {
-			// Check if preceded by a digit
-			prev := inputLower[i-1]
-			if prev >= '0' && prev <= '9' {
-				return true
-			}
+		prev := input[i-1]
+		if input[i:i+len(suffix)] != suffix || prev < '0' || prev > '9' {
+			continue
 		}
+		// A lone "m" is minutes, not the start of "mo"
+		if suffix == "m" && strings.HasPrefix(input[i+1:], "o") {
+			continue
+		}
+		return true
 	}
 	return false
 }
@@ -180,20 +446,24 @@ func formatForInput(d time.Duration) string {
 
 	var parts []string
 
-	// For long durations, use top 2 units
+	// For long durations, use top 2 units. Only units parseDuration accepts are
+	// emitted, so the result can be submitted unchanged.
 	if days > 0 {
 		years := days / 365
 		remainingDays := days % 365
-		months := remainingDays / 30
+		weeks := remainingDays / 7
 
 		if years > 0 {
 			parts = append(parts, fmt.Sprintf("%dy", years))
 		}
-		if months > 0 {
-			parts = append(parts, fmt.Sprintf("%dmo", months))
+		if weeks > 0 {
+			parts = append(parts, fmt.Sprintf("%dw", weeks))
+		}
+		if remainingDays%7 > 0 && len(parts) < 2 {
+			parts = append(parts, fmt.Sprintf("%dd", remainingDays%7))
 		}
-		if remainingDays%30 > 0 && len(parts) < 2 {
-			parts = append(parts, fmt.Sprintf("%dd", remainingDays%30))
+		if len(parts) < 2 && hours > 0 {
+			parts = append(parts, fmt.Sprintf("%dh", hours))
 		}
 	} else if hours > 0 {
 		parts = append(parts, fmt.Sprintf("%dh", hours))
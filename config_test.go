@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSameMonthFormat(t *testing.T) {
+	useTempFiles(t)
+	writeConfig(t, `{"sameMonthFormat": "Mon 2 15:04"}`)
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	layouts := cfg.endTimeLayouts(tuiEndTimeLayouts)
+	tests := []struct {
+		end  time.Time
+		want string
+	}{
+		{testNow.Add(time.Hour), "13:00:00"},
+		{testNow.Add(2 * 24 * time.Hour), "Thu 12 12:00"},
+		{testNow.Add(30 * 24 * time.Hour), "9/04 12:00"},
+	}
+	for _, tt := range tests {
+		if got := formatEndTime(tt.end, testNow, time.UTC, layouts); got != tt.want {
+			t.Errorf("formatEndTime(%v) = %q, want %q", tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidSameMonthFormatIsIgnored(t *testing.T) {
+	useTempFiles(t)
+	writeConfig(t, `{"sameMonthFormat": "soon"}`)
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SameMonthFormat != "" {
+		t.Errorf("SameMonthFormat = %q, want it dropped", cfg.SameMonthFormat)
+	}
+	if got := cfg.endTimeLayouts(cliEndTimeLayouts).sameMonth; got != cliEndTimeLayouts.sameMonth {
+		t.Errorf("sameMonth layout = %q, want the default %q", got, cliEndTimeLayouts.sameMonth)
+	}
+}
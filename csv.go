@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// writeCSV writes timers as CSV with a header row: name, status, duration and
+// remaining in whole seconds, and the end time in RFC 3339
+func writeCSV(w io.Writer, timers []Timer, now time.Time) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"name", "status", "duration_seconds", "remaining_seconds", "end_rfc3339"}); err != nil {
+		return err
+	}
+	for _, t := range timers {
+		remaining := max(t.remainingAt(now), 0)
+		record := []string{
+			t.Name,
+			t.status(now),
+			strconv.FormatInt(int64(t.Duration.Round(time.Second).Seconds()), 10),
+			strconv.FormatInt(int64(remaining.Round(time.Second).Seconds()), 10),
+			t.End.Format(time.RFC3339),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// builtinEvents are annual events as "MM-DD"
+var builtinEvents = map[string]string{
+	"newyear":   "01-01",
+	"valentine": "02-14",
+	"halloween": "10-31",
+	"christmas": "12-25",
+}
+
+// knownEvents returns the sorted names of built-in and configured events
+func knownEvents(custom map[string]string) []string {
+	var names []string
+	for name := range builtinEvents {
+		names = append(names, name)
+	}
+	for name := range custom {
+		if _, ok := builtinEvents[strings.ToLower(name)]; !ok {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nextEventOccurrence returns the start (local midnight) of the next occurrence
+// of a named event. Dates are "MM-DD" for annual events or "YYYY-MM-DD" for
+// one-off events; configured events take precedence over built-in ones.
+func nextEventOccurrence(name string, custom map[string]string, now time.Time) (time.Time, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	date, ok := "", false
+	for k, v := range custom {
+		if strings.ToLower(k) == name {
+			date, ok = v, true
+			break
+		}
+	}
+	if !ok {
+		date, ok = builtinEvents[name]
+	}
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown event %q (known: %s)", name, strings.Join(knownEvents(custom), ", "))
+	}
+
+	// One-off event with a full date
+	if t, err := time.ParseInLocation("2006-01-02", date, now.Location()); err == nil {
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("event %q on %s has already passed", name, date)
+		}
+		return t, nil
+	}
+
+	// Annual event: this year's date, or next year's if it has passed
+	md, err := time.Parse("01-02", date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q for event %q (use MM-DD or YYYY-MM-DD)", date, name)
+	}
+	for year := now.Year(); year <= now.Year()+1; year++ {
+		t := time.Date(year, md.Month(), md.Day(), 0, 0, 0, 0, now.Location())
+		if t.After(now) {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no upcoming occurrence of %q", name)
+}
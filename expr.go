@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exprOperators are the characters that make a duration input an expression
+const exprOperators = "+-*()"
+
+// exprValue is either a duration or a plain number (scalar) used as a multiplier
+type exprValue struct {
+	d      time.Duration // duration, or the number itself when scalar
+	scalar bool
+}
+
+// durationExprParser is a small recursive-descent parser for duration expressions:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = operand { "*" operand }
+//	operand = "(" sum ")" | number | duration
+type durationExprParser struct {
+	input string
+	pos   int
+}
+
+// evalDurationExpr evaluates expressions like "3*(25m+5m)", "2*1h30m+10m" or "1h-10m"
+func evalDurationExpr(input string) (time.Duration, error) {
+	p := &durationExprParser{input: input}
+
+	v, err := p.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+
+	d := v.d
+	if v.scalar {
+		// A bare number means seconds, same as the simple parser
+		d, err = mulDuration(time.Second, int64(v.d))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
+
+func (p *durationExprParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// accept consumes the given operator if it is next in the input
+func (p *durationExprParser) accept(op byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *durationExprParser) parseSum() (exprValue, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return exprValue{}, err
+	}
+
+	for {
+		subtract := false
+		switch {
+		case p.accept('+'):
+		case p.accept('-'):
+			subtract = true
+		default:
+			return left, nil
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if left.scalar != right.scalar {
+			return exprValue{}, fmt.Errorf("cannot add or subtract a plain number and a duration")
+		}
+		if subtract {
+			// Parts may go negative as long as the whole expression ends up positive
+			if left.d < math.MinInt64+right.d {
+				return exprValue{}, fmt.Errorf("duration too large")
+			}
+			left.d -= right.d
+			continue
+		}
+		if left.d, err = addDuration(left.d, right.d); err != nil {
+			return exprValue{}, err
+		}
+	}
+}
+
+func (p *durationExprParser) parseProduct() (exprValue, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return exprValue{}, err
+	}
+
+	for p.accept('*') {
+		right, err := p.parseOperand()
+		if err != nil {
+			return exprValue{}, err
+		}
+		switch {
+		case !left.scalar && !right.scalar:
+			return exprValue{}, fmt.Errorf("cannot multiply two durations")
+		case left.scalar:
+			right.d, err = mulDuration(right.d, int64(left.d))
+			left = right
+		default:
+			left.d, err = mulDuration(left.d, int64(right.d))
+		}
+		if err != nil {
+			return exprValue{}, err
+		}
+	}
+	return left, nil
+}
+
+func (p *durationExprParser) parseOperand() (exprValue, error) {
+	p.skipSpaces()
+
+	if p.accept('(') {
+		v, err := p.parseSum()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if !p.accept(')') {
+			return exprValue{}, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		return v, nil
+	}
+
+	// Read everything up to the next operator as a single term
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(exprOperators, rune(p.input[p.pos])) {
+		p.pos++
+	}
+	term := strings.TrimSpace(p.input[start:p.pos])
+	if term == "" {
+		return exprValue{}, fmt.Errorf("expected number at position %d", start)
+	}
+
+	// Plain numbers are scalars so they can be used as multipliers
+	if n, err := strconv.Atoi(term); err == nil {
+		if n < 0 {
+			return exprValue{}, fmt.Errorf("duration must be positive")
+		}
+		return exprValue{d: time.Duration(n), scalar: true}, nil
+	}
+
+	d, err := parseSimpleDuration(term)
+	if err != nil {
+		return exprValue{}, err
+	}
+	return exprValue{d: d}, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvalDurationExpr(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"25m+5m", 30 * time.Minute},
+		{"3*(25m+5m)", 90 * time.Minute},
+		{"2*25m+5m", 55 * time.Minute}, // * binds tighter than +
+		{"5m+2*25m", 55 * time.Minute},
+		{"25m*2", 50 * time.Minute},
+		{"2*1h30m+10m", 3*time.Hour + 10*time.Minute},
+		{"1h-10m", 50 * time.Minute},
+		{"1h-10m-20m", 30 * time.Minute}, // left to right
+		{"1h-(30m-10m)", 40 * time.Minute},
+		{"10m-20m+1h", 50 * time.Minute}, // negative along the way is fine
+		{" ( 1h + 30m ) * 2 ", 3 * time.Hour},
+		{"2*3", 6 * time.Second}, // bare numbers are seconds
+		{"((5m))", 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := evalDurationExpr(tt.input)
+		if err != nil {
+			t.Errorf("evalDurationExpr(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("evalDurationExpr(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestEvalDurationExprInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"25m+",
+		"+25m",
+		"(25m+5m",
+		"25m+5m)",
+		"25m*5m",        // two durations
+		"2+5m",          // number and duration
+		"10m-1h",        // not positive
+		"5m-5m",         // zero
+		"0*5m",          // zero
+		"25x+5m",        // unknown unit
+		"1000000y*1000", // overflow
+	} {
+		if d, err := evalDurationExpr(input); err == nil {
+			t.Errorf("evalDurationExpr(%q) = %v, want error", input, d)
+		}
+	}
+}
+
+func TestParseDurationUsesExpressions(t *testing.T) {
+	d, err := parseDuration("1H-15M")
+	if err != nil || d != 45*time.Minute {
+		t.Errorf("parseDuration(\"1H-15M\") = %v, %v; want 45m", d, err)
+	}
+}
+
+func TestValidateDurationInputAllowsOperators(t *testing.T) {
+	for _, s := range []string{"3*(25m+5m)", "1h-10m", "2mo", "1.5h"} {
+		if err := validateDurationInput(s); err != nil {
+			t.Errorf("validateDurationInput(%q) = %v, want nil", s, err)
+		}
+	}
+	for _, s := range []string{"5x", "1h/2", "abc"} {
+		if err := validateDurationInput(s); err == nil {
+			t.Errorf("validateDurationInput(%q) = nil, want error", s)
+		}
+	}
+}
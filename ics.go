@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icsTimeLayout is the iCalendar UTC date-time format
+const icsTimeLayout = "20060102T150405Z"
+
+// icsEscape escapes text values as required by RFC 5545
+func icsEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// icsFold splits a content line into 75-octet pieces joined by CRLF and a
+// space, without breaking UTF-8 sequences
+func icsFold(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := len(string(r))
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
+
+// writeICS writes a VCALENDAR with one zero-length VEVENT at the end of each
+// running timer. Paused timers have no fixed end and are left out.
+func writeICS(w io.Writer, timers []Timer, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//go-countdown//EN",
+		"CALSCALE:GREGORIAN",
+	}
+	for _, t := range timers {
+		if t.Paused {
+			continue
+		}
+		end := t.End.UTC().Format(icsTimeLayout)
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+t.ID+"@go-countdown",
+			"DTSTAMP:"+now.UTC().Format(icsTimeLayout),
+			"DTSTART:"+end,
+			"DTEND:"+end,
+			"SUMMARY:"+icsEscape(t.Name),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := fmt.Fprint(w, icsFold(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
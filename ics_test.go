@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICS(t *testing.T) {
+	end := time.Date(2026, time.March, 10, 14, 30, 0, 0, time.FixedZone("CET", 3600))
+	timers := []Timer{
+		{ID: "abc", Name: "Call, Bob; re: x", End: end, Duration: time.Hour},
+		{ID: "p", Name: "Paused", Paused: true, Remaining: time.Minute, Duration: time.Hour},
+	}
+	var b strings.Builder
+	if err := writeICS(&b, timers, testNow); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	if !strings.HasSuffix(out, "\r\n") || strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Fatalf("lines must end in CRLF only:\n%q", out)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Errorf("not wrapped in a VCALENDAR: %q", lines)
+	}
+
+	want := []string{
+		"BEGIN:VEVENT",
+		"UID:abc@go-countdown",
+		"DTSTAMP:20260310T120000Z",
+		"DTSTART:20260310T133000Z", // end in UTC
+		"DTEND:20260310T133000Z",
+		`SUMMARY:Call\, Bob\; re: x`,
+		"END:VEVENT",
+	}
+	start := strings.Index(out, "BEGIN:VEVENT")
+	if start < 0 {
+		t.Fatalf("no VEVENT in:\n%s", out)
+	}
+	got := strings.Split(out[start:], "\r\n")[:len(want)]
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("VEVENT line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 1 {
+		t.Errorf("got %d VEVENTs, want 1 (paused timers are left out)", n)
+	}
+}
+
+func TestICSFoldLongLines(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	for i, part := range strings.Split(icsFold(line), "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line %d is %d octets, want at most 75", i, len(part))
+		}
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Errorf("continuation line %d doesn't start with a space", i)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package main
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"log"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type filterMode int
 
@@ -18,27 +24,55 @@ const (
 	bulkResumeAll
 	bulkDeleteDone
 	bulkRestartAll
+	bulkPauseVisible  // only the timers shown under the current filter and search
+	bulkResumeVisible // likewise, for timers paused by a bulk pause
 )
 
 // defaultKeyMap defines keybindings for the main timer view
 type defaultKeyMap struct {
 	Up         key.Binding
 	Down       key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	Top        key.Binding
+	Bottom     key.Binding
 	UpOrder    key.Binding
 	DownOrder  key.Binding
+	MoveTop    key.Binding
+	MoveBottom key.Binding
+	MoveTo     key.Binding
 	Add        key.Binding
 	Delete     key.Binding
+	QuickDel   key.Binding
+	Undo       key.Binding
 	DeleteDone key.Binding
 	Edit       key.Binding
+	Duplicate  key.Binding
+	Info       key.Binding
+	Copy       key.Binding
+	Pin        key.Binding
 	Redo       key.Binding
+	Reset      key.Binding
 	RestartAll key.Binding
 	Pause      key.Binding
+	Snooze     key.Binding
+	LessTime   key.Binding
+	MoreTime   key.Binding
 	PauseAll   key.Binding
 	ResumeAll  key.Binding
+	PauseVis   key.Binding
+	ResumeVis  key.Binding
+	NextFilter key.Binding
 	Filter1    key.Binding
 	Filter2    key.Binding
 	Filter3    key.Binding
 	Filter4    key.Binding
+	Density    key.Binding
+	Focus      key.Binding
+	Sequence   key.Binding
+	Sort       key.Binding
+	SortRev    key.Binding
+	Search     key.Binding
 	Help       key.Binding
 	Quit       key.Binding
 }
@@ -51,16 +85,37 @@ func (k defaultKeyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the full help view
 func (k defaultKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.UpOrder, k.DownOrder},
-		{k.Add, k.Delete, k.Edit, k.Redo, k.Pause},
-		{k.DeleteDone, k.RestartAll, k.PauseAll, k.ResumeAll},
-		{k.Filter1, k.Filter2, k.Filter3, k.Filter4},
-		{k.Help, k.Quit},
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.Top, k.Bottom, k.UpOrder, k.DownOrder, k.MoveTop, k.MoveBottom, k.MoveTo},
+		{k.Add, k.Delete, k.QuickDel, k.Undo, k.Edit, k.Duplicate, k.Info, k.Copy, k.Pin, k.Redo, k.Reset, k.Pause, k.Snooze, k.LessTime, k.MoreTime},
+		{k.DeleteDone, k.RestartAll, k.PauseAll, k.ResumeAll, k.PauseVis, k.ResumeVis},
+		{k.NextFilter, k.Filter1, k.Filter2, k.Filter3, k.Filter4, k.Search},
+		{k.Sort, k.SortRev},
+		{k.Density, k.Focus, k.Sequence, k.Help, k.Quit},
 	}
 }
 
-func newDefaultKeyMap() defaultKeyMap {
-	return defaultKeyMap{
+// bindings maps the action names used by the keybindings config to the
+// bindings they change
+func (k *defaultKeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up": &k.Up, "down": &k.Down, "pageUp": &k.PageUp, "pageDown": &k.PageDown,
+		"top": &k.Top, "bottom": &k.Bottom, "reorderUp": &k.UpOrder, "reorderDown": &k.DownOrder,
+		"moveTop": &k.MoveTop, "moveBottom": &k.MoveBottom, "moveTo": &k.MoveTo,
+		"add": &k.Add, "delete": &k.Delete, "quickDelete": &k.QuickDel, "undo": &k.Undo,
+		"deleteDone": &k.DeleteDone, "edit": &k.Edit, "duplicate": &k.Duplicate, "info": &k.Info,
+		"copy": &k.Copy, "pin": &k.Pin, "restart": &k.Redo, "reset": &k.Reset, "restartAll": &k.RestartAll, "pause": &k.Pause,
+		"snooze": &k.Snooze, "lessTime": &k.LessTime, "moreTime": &k.MoreTime,
+		"pauseAll": &k.PauseAll, "resumeAll": &k.ResumeAll, "pauseVisible": &k.PauseVis, "resumeVisible": &k.ResumeVis,
+		"nextFilter": &k.NextFilter, "filterAll": &k.Filter1, "filterActive": &k.Filter2,
+		"filterPaused": &k.Filter3, "filterDone": &k.Filter4, "density": &k.Density, "focus": &k.Focus, "sequence": &k.Sequence,
+		"sort": &k.Sort, "reverseSort": &k.SortRev, "search": &k.Search, "help": &k.Help, "quit": &k.Quit,
+	}
+}
+
+// newDefaultKeyMap returns the main view bindings, with any actions in custom
+// bound to the keys given there instead
+func newDefaultKeyMap(custom map[string][]string) defaultKeyMap {
+	k := defaultKeyMap{
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
 			key.WithHelp("↑/k", "move up"),
@@ -69,6 +124,22 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "move down"),
 		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+b"),
+			key.WithHelp("pgup/ctrl+b", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+f"),
+			key.WithHelp("pgdn/ctrl+f", "page down"),
+		),
+		Top: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "go to first"),
+		),
+		Bottom: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "go to last"),
+		),
 		UpOrder: key.NewBinding(
 			key.WithKeys("ctrl+up", "ctrl+k"),
 			key.WithHelp("ctrl+↑", "reorder up"),
@@ -77,6 +148,18 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithKeys("ctrl+down", "ctrl+j"),
 			key.WithHelp("ctrl+↓", "reorder down"),
 		),
+		MoveTop: key.NewBinding(
+			key.WithKeys("ctrl+t", "ctrl+home"),
+			key.WithHelp("ctrl+t", "move to top"),
+		),
+		MoveBottom: key.NewBinding(
+			key.WithKeys("ctrl+e", "ctrl+end"),
+			key.WithHelp("ctrl+e", "move to bottom"),
+		),
+		MoveTo: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move to position"),
+		),
 		Add: key.NewBinding(
 			key.WithKeys("a"),
 			key.WithHelp("a", "add timer"),
@@ -85,6 +168,14 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "delete timer"),
 		),
+		QuickDel: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "delete (no confirm)"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo delete"),
+		),
 		DeleteDone: key.NewBinding(
 			key.WithKeys("D"),
 			key.WithHelp("D", "delete all done"),
@@ -93,10 +184,30 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit timer"),
 		),
+		Duplicate: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "duplicate"),
+		),
+		Info: key.NewBinding(
+			key.WithKeys("i", "enter"),
+			key.WithHelp("i/enter", "details"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy remaining"),
+		),
+		Pin: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "pin/unpin"),
+		),
 		Redo: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "restart timer"),
 		),
+		Reset: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "reset (paused)"),
+		),
 		RestartAll: key.NewBinding(
 			key.WithKeys("R"),
 			key.WithHelp("R", "restart all"),
@@ -105,13 +216,37 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithKeys("p"),
 			key.WithHelp("p", "pause/resume"),
 		),
+		Snooze: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "snooze"),
+		),
+		LessTime: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "less time"),
+		),
+		MoreTime: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "more time"),
+		),
 		PauseAll: key.NewBinding(
 			key.WithKeys("P"),
 			key.WithHelp("P", "pause all"),
 		),
 		ResumeAll: key.NewBinding(
-			key.WithKeys("shift+r"),
-			key.WithHelp("shift+R", "resume all"),
+			key.WithKeys("U"),
+			key.WithHelp("U", "resume all"),
+		),
+		PauseVis: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "pause visible"),
+		),
+		ResumeVis: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "resume visible"),
+		),
+		NextFilter: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next filter"),
 		),
 		Filter1: key.NewBinding(
 			key.WithKeys("1"),
@@ -129,6 +264,30 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithKeys("4"),
 			key.WithHelp("4", "show done"),
 		),
+		Density: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "compact/detailed"),
+		),
+		Focus: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "focus mode"),
+		),
+		Sequence: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "tag timeline"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "cycle sort"),
+		),
+		SortRev: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse sort"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search names"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
@@ -138,6 +297,8 @@ func newDefaultKeyMap() defaultKeyMap {
 			key.WithHelp("q", "quit"),
 		),
 	}
+	applyKeybindings(k.bindings(), custom)
+	return k
 }
 
 // formKeyMap defines keybindings for adding/editing timers
@@ -149,6 +310,11 @@ type formKeyMap struct {
 	Help      key.Binding
 	Increase  key.Binding // + or = key
 	Decrease  key.Binding // - or _ key
+
+	Clear        key.Binding // empty the focused input
+	ToggleUntil  key.Binding // switch between duration and end time
+	TogglePaused key.Binding // create the new timer paused
+	KeepProgress key.Binding // edit without restarting the countdown
 }
 
 // ShortHelp returns keybindings for the mini help view
@@ -159,14 +325,26 @@ func (k formKeyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the full help view
 func (k formKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.NextField, k.PrevField},
-		{k.Increase, k.Decrease},
+		{k.NextField, k.PrevField, k.Clear},
+		{k.Increase, k.Decrease, k.ToggleUntil, k.TogglePaused, k.KeepProgress},
 		{k.Enter, k.Esc},
 	}
 }
 
-func newFormKeyMap() formKeyMap {
-	return formKeyMap{
+// bindings maps the action names used by the keybindings config to the
+// bindings they change. Enter and esc always submit and cancel the form.
+func (k *formKeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"nextField": &k.NextField, "prevField": &k.PrevField, "increase": &k.Increase, "decrease": &k.Decrease,
+		"clearField": &k.Clear, "toggleUntil": &k.ToggleUntil, "togglePaused": &k.TogglePaused,
+		"keepProgress": &k.KeepProgress,
+	}
+}
+
+// newFormKeyMap returns the add/edit form bindings, with any actions in custom
+// bound to the keys given there instead
+func newFormKeyMap(custom map[string][]string) formKeyMap {
+	k := formKeyMap{
 		NextField: key.NewBinding(
 			key.WithKeys("tab", "down"),
 			key.WithHelp("tab/↓", "next field"),
@@ -183,6 +361,22 @@ func newFormKeyMap() formKeyMap {
 			key.WithKeys("-", "_"),
 			key.WithHelp("-/_", "decrease duration"),
 		),
+		Clear: key.NewBinding(
+			key.WithKeys("ctrl+u"),
+			key.WithHelp("ctrl+u", "clear field"),
+		),
+		ToggleUntil: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "duration/until"),
+		),
+		TogglePaused: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "start paused"),
+		),
+		KeepProgress: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "keep progress"),
+		),
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "confirm/next"),
@@ -196,6 +390,66 @@ func newFormKeyMap() formKeyMap {
 			key.WithHelp("?", "toggle help"),
 		),
 	}
+	applyKeybindings(k.bindings(), custom)
+	return k
+}
+
+// applyKeybindings rebinds the named actions to their custom keys, keeping
+// the help descriptions. Names that aren't in bindings are left for the
+// other keymap.
+func applyKeybindings(bindings map[string]*key.Binding, custom map[string][]string) {
+	for name, keys := range custom {
+		if b, ok := bindings[name]; ok && len(keys) > 0 {
+			b.SetKeys(keys...)
+			b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+		}
+	}
+}
+
+// keyNames holds the names bubbletea gives keys that aren't plain characters,
+// such as "enter", "pgdown" or "ctrl+a"
+var keyNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for k := tea.KeyType(-128); k < 128; k++ {
+		if name := k.String(); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}()
+
+// validKeyName reports whether name is a key as bubbletea reports it: a
+// single character or a named key, optionally with an "alt+" prefix
+func validKeyName(name string) bool {
+	name = strings.TrimPrefix(name, "alt+")
+	return len([]rune(name)) == 1 || keyNames[name]
+}
+
+// validKeybindings drops unknown actions and invalid key names from the
+// keybindings config, warning about each
+func validKeybindings(custom map[string][]string) map[string][]string {
+	defaults, form := newDefaultKeyMap(nil), newFormKeyMap(nil)
+	actions, formActions := defaults.bindings(), form.bindings()
+
+	valid := make(map[string][]string)
+	for name, keys := range custom {
+		if actions[name] == nil && formActions[name] == nil {
+			log.Printf("warning: unknown keybinding action %q, ignoring", name)
+			continue
+		}
+		var good []string
+		for _, k := range keys {
+			if validKeyName(k) {
+				good = append(good, k)
+			} else {
+				log.Printf("warning: invalid key %q for %q, ignoring", k, name)
+			}
+		}
+		if len(good) > 0 {
+			valid[name] = good
+		}
+	}
+	return valid
 }
 
 // confirmKeyMap defines keybindings for delete confirmation
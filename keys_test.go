@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultKeysDoNotCollide(t *testing.T) {
+	k := newDefaultKeyMap(nil)
+	owner := map[string]string{}
+	for action, b := range k.bindings() {
+		for _, name := range b.Keys() {
+			if other, ok := owner[name]; ok {
+				t.Errorf("key %q is bound to both %s and %s", name, other, action)
+			}
+			owner[name] = action
+		}
+	}
+}
+
+func TestResumeAllKeyCanBePressed(t *testing.T) {
+	useTempFiles(t)
+	m := newTestModel(t, []Timer{
+		{ID: "a", Name: "bulk", Duration: time.Minute, End: testNow.Add(time.Minute), Started: testNow},
+		{ID: "b", Name: "manual", Duration: time.Minute, Paused: true, Remaining: time.Minute},
+	})
+	press := func(keys string) {
+		for _, r := range keys {
+			next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = next.(model)
+		}
+	}
+
+	press("Py")
+	if !m.timers[0].Paused || !m.timers[0].BulkPaused {
+		t.Fatalf("P then y left the running timer %+v, want it bulk-paused", m.timers[0])
+	}
+
+	press("U")
+	if m.state != stateConfirmBulk || m.pendingBulkAction != bulkResumeAll {
+		t.Fatalf("U opened state %v with action %v, want the resume all confirmation", m.state, m.pendingBulkAction)
+	}
+	press("y")
+	if m.timers[0].Paused {
+		t.Error("timer paused by P is still paused after U and y")
+	}
+	if !m.timers[1].Paused {
+		t.Error("timer paused by hand was resumed by U")
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -24,10 +26,97 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Adjust table width based on available space (filter panel takes 20 chars)
 		tableWidth := msg.Width - 25 // Leave room for filter panel + padding
 		m.table.SetWidth(tableWidth)
-		m.table.SetHeight(msg.Height - 5) // Leave room for help
+		m.resizeTable()
+		refreshTableColumns(&m)
+		return m, nil
+
+	case tea.MouseMsg:
+		// The small-terminal fallback has no table to click
+		if m.state != stateDefault || msg.Button != tea.MouseButtonLeft || m.tooSmall() {
+			if msg.Action == tea.MouseActionRelease {
+				m.dragging = false
+			}
+			return m, nil
+		}
+		switch msg.Action {
+		case tea.MouseActionPress:
+			// Pick up the row under the pointer (the table starts after the filter panel)
+			if row := m.rowAtY(msg.Y); row >= 0 && msg.X >= 20 {
+				m.setCursor(row)
+				m.dragging = true
+			}
+		case tea.MouseActionMotion:
+			if m.dragging {
+				if row := m.rowAtY(msg.Y); row >= 0 && row != m.cursor {
+					m.moveTimer(m.cursor, row)
+				}
+			}
+		case tea.MouseActionRelease:
+			m.dragging = false
+		}
 		return m, nil
 
 	case tea.KeyMsg:
+		// A corrupt timers file leaves the TUI read-only until it is fixed
+		// on disk (picked up by the file watcher) or explicitly reset
+		if m.loadErr != nil {
+			switch msg.String() {
+			case "X":
+				if _, err := backupCorruptSave(); err != nil {
+					m.loadErr = fmt.Errorf("%w (backup failed: %v)", errCorruptSave, err)
+					return m, nil
+				}
+				m.loadErr = nil
+				m.timers = nil
+				m.cursor = 0
+			case "q", "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// The search box takes all keys while focused
+		if m.searching {
+			var cmd tea.Cmd
+			switch msg.String() {
+			case "esc":
+				// Clear the search entirely
+				m.searching = false
+				m.searchInput.Reset()
+				m.searchInput.Blur()
+				m.setSearch("")
+			case "enter":
+				// Keep the query applied and return to the table
+				m.searching = false
+				m.searchInput.Blur()
+			default:
+				m.searchInput, cmd = m.searchInput.Update(msg)
+				m.setSearch(m.searchInput.Value())
+			}
+			return m, cmd
+		}
+
+		// The move prompt takes all keys while open
+		if m.moving {
+			var cmd tea.Cmd
+			switch msg.String() {
+			case "esc":
+				m.moving = false
+			case "enter":
+				if n, err := strconv.Atoi(m.moveInput.Value()); err == nil && n >= 1 {
+					m.moveTimer(m.cursor, min(n, len(m.getVisibleTimers()))-1)
+				}
+				m.moving = false
+			default:
+				m.moveInput, cmd = m.moveInput.Update(msg)
+			}
+			if !m.moving {
+				m.moveInput.Reset()
+				m.moveInput.Blur()
+			}
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, m.defaultKeys.Help):
 			if m.state == stateDefault {
@@ -43,437 +132,434 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch {
 			case key.Matches(msg, m.formKeys.NextField):
 				// Move to next field (tab or down arrow)
-				if m.nameInput.Focused() {
-					m.nameInput.Blur()
-					m.durationInput.Focus()
-				} else {
-					m.durationInput.Blur()
-					m.nameInput.Focus()
-				}
+				m.focusFormInput(1)
 				return m, nil
 
 			case key.Matches(msg, m.formKeys.PrevField):
 				// Move to previous field (shift+tab or up arrow)
-				if m.nameInput.Focused() {
-					m.nameInput.Blur()
-					m.durationInput.Focus()
-				} else {
-					m.durationInput.Blur()
-					m.nameInput.Focus()
-				}
+				m.focusFormInput(-1)
 				return m, nil
 
-			case key.Matches(msg, m.formKeys.Increase):
-				if m.durationInput.Focused() {
-					current := m.durationInput.Value()
-					step := time.Duration(m.durationConfig.IncrementStep)
-					delta := step * getUnitMultiplier(m.durationConfig.Unit, current)
-					newValue := adjustDuration(current, delta, m.durationConfig)
-					m.durationInput.SetValue(newValue)
-				}
+			case key.Matches(msg, m.formKeys.Clear):
+				m.clearFocusedInput()
 				return m, nil
 
-			case key.Matches(msg, m.formKeys.Decrease):
-				if m.durationInput.Focused() {
-					current := m.durationInput.Value()
-					step := time.Duration(m.durationConfig.IncrementStep)
-					delta := step * getUnitMultiplier(m.durationConfig.Unit, current)
-					newValue := adjustDuration(current, -delta, m.durationConfig)
-					m.durationInput.SetValue(newValue)
-				}
+			case key.Matches(msg, m.formKeys.ToggleUntil):
+				m.setUntilMode(!m.untilMode)
+				return m, nil
+
+			case key.Matches(msg, m.formKeys.TogglePaused) && m.state == stateAdding:
+				m.startPaused = !m.startPaused
+				return m, nil
+
+			case key.Matches(msg, m.formKeys.KeepProgress) && m.state == stateEditing:
+				m.keepProgress = !m.keepProgress
+				return m, nil
+
+			// +/- adjust a duration; elsewhere, and once the duration is an
+			// expression like "(25m+5m)" or "2*25m", they are typed as usual
+			case key.Matches(msg, m.formKeys.Increase) && m.adjustingDuration():
+				current := m.durationInput.Value()
+				step := time.Duration(m.durationConfig.IncrementStep)
+				delta := step * getUnitMultiplier(m.durationConfig.Unit, current)
+				newValue := adjustDuration(current, delta, m.durationConfig)
+				m.durationInput.SetValue(newValue)
+				return m, nil
+
+			case key.Matches(msg, m.formKeys.Decrease) && m.adjustingDuration():
+				current := m.durationInput.Value()
+				step := time.Duration(m.durationConfig.IncrementStep)
+				delta := step * getUnitMultiplier(m.durationConfig.Unit, current)
+				newValue := adjustDuration(current, -delta, m.durationConfig)
+				m.durationInput.SetValue(newValue)
 				return m, nil
 
 			case msg.String() == "enter":
 				// Validate and submit
 				name := m.nameInput.Value()
-				durationStr := m.durationInput.Value()
 
 				// Name is required
-				if name == "" {
+				if strings.TrimSpace(name) == "" {
+					m.rejectForm("Name is required", &m.nameInput)
 					return m, nil
 				}
 
-				// Validate duration
-				duration, err := parseDuration(durationStr)
+				// Validate duration (or end time in until mode)
+				duration, err := m.formDuration()
 				if err != nil {
+					field := "Duration"
+					if m.untilMode {
+						field = "Until"
+					}
+					m.rejectForm(field+": "+err.Error(), &m.durationInput)
+					return m, nil
+				}
+				if _, err := m.formRepeat(); err != nil {
+					m.rejectForm("Repeat: "+err.Error(), &m.repeatInput)
 					return m, nil
 				}
 
-				if m.state == stateEditing {
-					// Update existing timer
-					m.timers[m.editingIndex].Name = name
-					m.timers[m.editingIndex].End = time.Now().Add(duration)
-					m.timers[m.editingIndex].Duration = duration
-					m.timers[m.editingIndex].Paused = false
-					m.timers[m.editingIndex].Remaining = 0
-				} else {
-					// Add new timer
-					newTimer := Timer{
-						Name:     name,
-						End:      time.Now().Add(duration),
-						Duration: duration,
-					}
-					m.timers = append(m.timers, newTimer)
-					visibleTimers := m.getVisibleTimers()
-					m.cursor = len(visibleTimers) - 1
+				// Ask before creating timers long enough to be a likely typo
+				if m.durationConfig.ConfirmLongDurations && duration > m.durationConfig.longDurationThreshold() {
+					m.formState = m.state
+					m.pendingDuration = duration
+					m.state = stateConfirmLong
+					return m, nil
 				}
-				m.dirty = true
 
-				// Reset and close form
-				m.state = stateDefault
-				m.nameInput.Reset()
-				m.durationInput.Reset()
-				m.nameInput.Focus()
-				return m, tick()
+				m.submitForm(m.state == stateEditing, name, duration)
+				return m, nil
 
 			case msg.String() == "esc":
 				// Cancel and close form
 				m.state = stateDefault
-				m.nameInput.Reset()
-				m.durationInput.Reset()
-				m.nameInput.Focus()
+				m.resetForm()
 				return m, nil
 
 			default:
-				// Update the focused input
-				if m.nameInput.Focused() {
-					m.nameInput, cmd = m.nameInput.Update(msg)
-				} else {
-					m.durationInput, cmd = m.durationInput.Update(msg)
+				// Update the focused input; typing clears the last error
+				m.formError = ""
+				for _, in := range m.formInputs() {
+					if in.Focused() {
+						*in, cmd = in.Update(msg)
+					}
 				}
 				return m, cmd
 			}
 		}
 
-		// Block all keys except y/n/esc/enter when confirming delete, restart, or bulk action
-		if m.state == stateConfirmDelete || m.state == stateConfirmRestart || m.state == stateConfirmBulk {
+		// Long duration confirmation returns to the form when declined
+		if m.state == stateConfirmLong {
 			switch msg.String() {
-			case "y", "Y", "n", "N", "d", "esc", "enter":
-				// These keys are handled below
+			case "y", "Y", "enter":
+				m.submitForm(m.formState == stateEditing, m.nameInput.Value(), m.pendingDuration)
+				return m, nil
+			case "n", "N", "esc":
+				m.state = m.formState
+			}
+			return m, nil
+		}
+
+		// The details popup only needs closing
+		if m.state == stateInfo {
+			if msg.String() == "esc" || key.Matches(msg, m.defaultKeys.Info, m.defaultKeys.Quit) {
+				m.state = stateDefault
+			}
+			return m, nil
+		}
+
+		// Focus mode only switches between timers and pauses them
+		if m.focusMode {
+			switch {
+			case msg.String() == "esc", key.Matches(msg, m.defaultKeys.Focus):
+				m.focusMode = false
+				return m, nil
+			case key.Matches(msg, m.defaultKeys.Up, m.defaultKeys.Down, m.defaultKeys.Pause, m.defaultKeys.Quit):
+				// Handled below like in the table
 			default:
 				return m, nil
 			}
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		// Confirmation popups only answer yes or no; the delete key confirms a delete too
+		if m.confirming() {
+			switch {
+			case key.Matches(msg, m.confirmKeys.ConfirmYes),
+				m.state == stateConfirmDelete && key.Matches(msg, m.defaultKeys.Delete):
+				m.confirmAction()
+			case key.Matches(msg, m.confirmKeys.ConfirmNo), key.Matches(msg, m.confirmKeys.Esc):
+				m.state = stateDefault
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.defaultKeys.Quit):
 			if m.dirty {
-				_ = saveToFile(m)
-				// Update lastModTime to avoid triggering reload on our own save
-				if info, err := os.Stat(saveFile); err == nil {
-					m.lastModTime = info.ModTime()
-				}
+				m.save()
 			}
 			return m, tea.Quit
 
-		case "p":
+		case key.Matches(msg, m.defaultKeys.Pause):
 			actualIdx := m.getActualTimerIndex(m.cursor)
 			if actualIdx >= 0 && len(m.timers) > 0 {
 				t := &m.timers[actualIdx]
 				if !t.Paused {
 					// Pause: only if timer is still running
-					if t.End.After(time.Now()) {
-						t.Remaining = time.Until(t.End)
-						t.Paused = true
+					if t.pause(nowFunc()) {
 						m.dirty = true
 					}
 				} else {
 					// Resume: always allow if we have remaining time
-					if t.Remaining > 0 {
-						t.End = time.Now().Add(t.Remaining)
-						t.Paused = false
+					if t.resume(nowFunc()) {
 						m.dirty = true
 					}
 				}
 			}
 			return m, nil
 
-		case "up", "k":
-			visibleTimers := m.getVisibleTimers()
+		case key.Matches(msg, m.defaultKeys.Up):
 			if m.cursor > 0 {
-				m.cursor--
-				m.table.SetCursor(m.cursor)
-			} else if len(visibleTimers) == 0 {
-				m.cursor = 0
-				m.table.SetCursor(0)
+				m.setCursor(m.cursor - 1)
 			}
 			return m, nil
 
-		case "down", "j":
+		case key.Matches(msg, m.defaultKeys.Down):
 			visibleTimers := m.getVisibleTimers()
 			if m.cursor < len(visibleTimers)-1 {
-				m.cursor++
-				m.table.SetCursor(m.cursor)
+				m.setCursor(m.cursor + 1)
 			}
 			return m, nil
 
-		case "ctrl+k", "ctrl+up":
-			actualIdx := m.getActualTimerIndex(m.cursor)
-			if actualIdx > 0 {
-				// Swap with previous timer
-				m.timers[actualIdx-1], m.timers[actualIdx] = m.timers[actualIdx], m.timers[actualIdx-1]
-				if m.cursor > 0 {
-					m.cursor--
-				}
-				m.dirty = true
-			}
+		case key.Matches(msg, m.defaultKeys.PageUp):
+			m.setCursor(max(m.cursor-m.table.Height(), 0))
 			return m, nil
 
-		case "ctrl+j", "ctrl+down":
-			actualIdx := m.getActualTimerIndex(m.cursor)
-			if actualIdx < len(m.timers)-1 && actualIdx >= 0 {
-				// Swap with next timer
-				m.timers[actualIdx], m.timers[actualIdx+1] = m.timers[actualIdx+1], m.timers[actualIdx]
-				visibleTimers := m.getVisibleTimers()
-				if m.cursor < len(visibleTimers)-1 {
-					m.cursor++
-				}
-				m.dirty = true
+		case key.Matches(msg, m.defaultKeys.PageDown):
+			m.setCursor(max(min(m.cursor+m.table.Height(), len(m.getVisibleTimers())-1), 0))
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Top):
+			m.setCursor(0)
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Bottom):
+			// Stays at 0 when nothing is visible
+			m.setCursor(max(len(m.getVisibleTimers())-1, 0))
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.UpOrder):
+			// Swap with the timer shown above; pinned timers stay in their group
+			if m.cursor > 0 {
+				m.moveTimer(m.cursor, m.cursor-1)
 			}
 			return m, nil
 
-		case "d":
-			actualIdx := m.getActualTimerIndex(m.cursor)
-			if actualIdx < 0 || len(m.timers) == 0 {
-				return m, nil
+		case key.Matches(msg, m.defaultKeys.DownOrder):
+			// Swap with the timer shown below
+			if m.cursor < len(m.getVisibleTimers())-1 {
+				m.moveTimer(m.cursor, m.cursor+1)
 			}
+			return m, nil
 
-			if m.state == stateConfirmDelete {
-				// Confirm delete
-				m.timers = append(
-					m.timers[:actualIdx],
-					m.timers[actualIdx+1:]...,
-				)
+		case key.Matches(msg, m.defaultKeys.Pin):
+			m.togglePin()
+			return m, nil
 
-				visibleTimers := m.getVisibleTimers()
-				if m.cursor >= len(visibleTimers) && m.cursor > 0 {
-					m.cursor--
-				}
+		case key.Matches(msg, m.defaultKeys.MoveTop):
+			// Move selected timer to the top in one step
+			m.moveTimer(m.cursor, 0)
+			return m, nil
 
-				m.state = stateDefault
+		case key.Matches(msg, m.defaultKeys.MoveBottom):
+			// Move selected timer to the bottom in one step
+			m.moveTimer(m.cursor, len(m.getVisibleTimers())-1)
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Delete):
+			if m.getActualTimerIndex(m.cursor) >= 0 {
+				m.openConfirm(stateConfirmDelete)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Snooze):
+			if actualIdx := m.getActualTimerIndex(m.cursor); actualIdx >= 0 {
+				m.timers[actualIdx].snooze(m.now, m.durationConfig.snoozeDuration())
+				m.selectTimer(m.timers[actualIdx])
 				m.dirty = true
-			} else {
-				// Show confirmation
-				m.state = stateConfirmDelete
 			}
 			return m, nil
 
-		case "y", "Y", "enter":
-			if m.state == stateConfirmDelete {
-				actualIdx := m.getActualTimerIndex(m.cursor)
-				// Confirm delete
-				if actualIdx >= 0 && len(m.timers) > 0 {
-					m.timers = append(
-						m.timers[:actualIdx],
-						m.timers[actualIdx+1:]...,
-					)
+		case key.Matches(msg, m.defaultKeys.LessTime):
+			m.adjustSelectedRemaining(-1)
+			return m, nil
 
-					visibleTimers := m.getVisibleTimers()
-					if m.cursor >= len(visibleTimers) && m.cursor > 0 {
-						m.cursor--
-					}
+		case key.Matches(msg, m.defaultKeys.MoreTime):
+			m.adjustSelectedRemaining(1)
+			return m, nil
 
-					m.state = stateDefault
-					m.dirty = true
-				}
-			}
-			if m.state == stateConfirmRestart {
-				actualIdx := m.getActualTimerIndex(m.cursor)
-				// Confirm restart
-				if actualIdx >= 0 && len(m.timers) > 0 && m.timers[actualIdx].Duration > 0 {
-					t := &m.timers[actualIdx]
-					t.End = m.now.Add(t.Duration)
-					t.Paused = false
-					t.Remaining = 0
-					m.state = stateDefault
-					m.dirty = true
-					return m, tick()
-				}
-			}
-			if m.state == stateConfirmBulk {
-				// Execute bulk action
-				switch m.pendingBulkAction {
-				case bulkPauseAll:
-					count := 0
-					for i := range m.timers {
-						if !m.timers[i].Paused && m.timers[i].End.After(m.now) {
-							m.timers[i].Remaining = time.Until(m.timers[i].End)
-							m.timers[i].Paused = true
-							count++
-						}
-					}
-					if count > 0 {
-						m.dirty = true
-					}
-				case bulkResumeAll:
-					count := 0
-					for i := range m.timers {
-						if m.timers[i].Paused && m.timers[i].Remaining > 0 {
-							m.timers[i].End = m.now.Add(m.timers[i].Remaining)
-							m.timers[i].Paused = false
-							count++
-						}
-					}
-					if count > 0 {
-						m.dirty = true
-					}
-				case bulkDeleteDone:
-					newTimers := make([]Timer, 0, len(m.timers))
-					for _, t := range m.timers {
-						if t.Paused || t.End.After(m.now) {
-							newTimers = append(newTimers, t)
-						} else {
-							m.dirty = true
-						}
-					}
-					m.timers = newTimers
-					// Adjust cursor if needed
-					visibleTimers := m.getVisibleTimers()
-					if m.cursor >= len(visibleTimers) && m.cursor > 0 {
-						m.cursor = len(visibleTimers) - 1
-					}
-				case bulkRestartAll:
-					count := 0
-					for i := range m.timers {
-						if m.timers[i].Duration > 0 {
-							m.timers[i].End = m.now.Add(m.timers[i].Duration)
-							m.timers[i].Paused = false
-							m.timers[i].Remaining = 0
-							count++
-						}
-					}
-					if count > 0 {
-						m.dirty = true
-					}
-				}
-				m.state = stateDefault
-			}
+		case key.Matches(msg, m.defaultKeys.Duplicate):
+			m.duplicateSelected()
 			return m, nil
 
-		case "n", "N", "esc":
-			if m.state == stateConfirmDelete || m.state == stateConfirmRestart || m.state == stateConfirmBulk {
-				m.state = stateDefault
-			}
+		case key.Matches(msg, m.defaultKeys.QuickDel):
+			m.quickDelete()
 			return m, nil
 
-		case "P":
-			if m.state == stateDefault {
-				m.state = stateConfirmBulk
-				m.pendingBulkAction = bulkPauseAll
-			}
+		case key.Matches(msg, m.defaultKeys.Undo):
+			m.undoDelete()
 			return m, nil
 
-		case "R":
-			if m.state == stateDefault {
-				m.state = stateConfirmBulk
-				m.pendingBulkAction = bulkRestartAll
-			}
+		case key.Matches(msg, m.defaultKeys.Copy):
+			m.copyRemaining()
 			return m, nil
 
-		case "shift+R":
-			if m.state == stateDefault {
-				m.state = stateConfirmBulk
-				m.pendingBulkAction = bulkResumeAll
-			}
+		case key.Matches(msg, m.defaultKeys.Info):
+			m.showInfo()
 			return m, nil
 
-		case "D":
-			if m.state == stateDefault {
-				m.state = stateConfirmBulk
-				m.pendingBulkAction = bulkDeleteDone
+		case msg.String() == "esc":
+			if m.searchQuery != "" {
+				m.searchInput.Reset()
+				m.setSearch("")
 			}
 			return m, nil
 
-		case "a":
-			if m.state != stateDefault {
-				return m, nil
+		case key.Matches(msg, m.defaultKeys.MoveTo):
+			// Positions only mean something in manual order
+			if m.canReorder() && m.getActualTimerIndex(m.cursor) >= 0 {
+				m.moving = true
+				m.moveInput.Placeholder = fmt.Sprintf("1-%d", len(m.getVisibleTimers()))
+				return m, m.moveInput.Focus()
 			}
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Search):
+			m.searching = true
+			return m, m.searchInput.Focus()
+
+		case key.Matches(msg, m.defaultKeys.PauseAll):
+			m.openConfirm(stateConfirmBulk)
+			m.pendingBulkAction = bulkPauseAll
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.RestartAll):
+			m.openConfirm(stateConfirmBulk)
+			m.pendingBulkAction = bulkRestartAll
+			return m, nil
 
+		case key.Matches(msg, m.defaultKeys.ResumeAll):
+			m.openConfirm(stateConfirmBulk)
+			m.pendingBulkAction = bulkResumeAll
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.PauseVis):
+			m.openConfirm(stateConfirmBulk)
+			m.pendingBulkAction = bulkPauseVisible
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.ResumeVis):
+			m.openConfirm(stateConfirmBulk)
+			m.pendingBulkAction = bulkResumeVisible
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.DeleteDone):
+			m.openConfirm(stateConfirmBulk)
+			m.pendingBulkAction = bulkDeleteDone
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Add):
 			m.state = stateAdding
-			m.nameInput.Reset()
-			m.durationInput.Reset()
-			m.nameInput.Focus()
-			m.durationInput.Blur()
+			m.resetForm()
+			m.durationInput.SetValue(m.durationConfig.DefaultDuration)
 			return m, nil
 
-		case "r":
+		case key.Matches(msg, m.defaultKeys.Redo):
 			actualIdx := m.getActualTimerIndex(m.cursor)
-			if actualIdx < 0 || len(m.timers) == 0 || m.timers[actualIdx].Duration == 0 {
-				return m, nil
+			if actualIdx >= 0 && m.timers[actualIdx].Duration > 0 {
+				m.openConfirm(stateConfirmRestart)
 			}
+			return m, nil
 
-			if m.state == stateConfirmRestart {
-				// Confirm restart
-				t := &m.timers[actualIdx]
-				t.End = time.Now().Add(t.Duration)
-				t.Paused = false
-				t.Remaining = 0
-				m.state = stateDefault
-				m.dirty = true
-				return m, tick()
-			} else {
-				// Show confirmation
-				m.state = stateConfirmRestart
+		case key.Matches(msg, m.defaultKeys.Reset):
+			actualIdx := m.getActualTimerIndex(m.cursor)
+			if actualIdx >= 0 && m.timers[actualIdx].Duration > 0 {
+				m.openConfirm(stateConfirmReset)
 			}
 			return m, nil
 
-		case "e":
+		case key.Matches(msg, m.defaultKeys.Edit):
 			actualIdx := m.getActualTimerIndex(m.cursor)
 			if actualIdx >= 0 && len(m.timers) > 0 {
 				m.state = stateEditing
 				m.editingIndex = actualIdx
-				m.nameInput.Reset()
-				m.durationInput.Reset()
+				m.resetForm()
 				m.nameInput.SetValue(m.timers[actualIdx].Name)
 				m.durationInput.SetValue(formatDuration(m.timers[actualIdx].Duration))
-				m.nameInput.Focus()
-				m.durationInput.Blur()
+				m.repeatInput.SetValue(formatForInput(m.timers[actualIdx].Repeat))
+				m.tagsInput.SetValue(strings.Join(m.timers[actualIdx].Tags, ", "))
+				m.noteInput.SetValue(m.timers[actualIdx].Note)
 			}
 			return m, nil
 
-		case "tab":
-			m.filter = (m.filter + 1) % 4
-			visibleTimers := m.getVisibleTimers()
-			if len(visibleTimers) == 0 {
-				m.cursor = 0
-			} else if m.cursor >= len(visibleTimers) {
-				m.cursor = len(visibleTimers) - 1
-			}
+		case key.Matches(msg, m.defaultKeys.Sequence):
+			m.sequence = !m.sequence
+			m.resizeTable()
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Sort):
+			m.sortMode = m.sortMode.next()
+			m.clampCursor()
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.SortRev):
+			m.sortReverse = !m.sortReverse
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Density):
+			m.compact = !m.compact
+			refreshTableColumns(&m)
+			_ = saveUIState(uiStateData{Compact: m.compact})
 			return m, nil
 
-		case "1":
-			m.filter = filterAll
-			m.cursor = 0
-			m.table.SetCursor(0)
-			m.table.GotoTop()
+		case key.Matches(msg, m.defaultKeys.Focus):
+			m.focusMode = m.getActualTimerIndex(m.cursor) >= 0
 			return m, nil
 
-		case "2":
-			m.filter = filterActive
-			m.cursor = 0
-			m.table.SetCursor(0)
-			m.table.GotoTop()
+		case key.Matches(msg, m.defaultKeys.NextFilter):
+			m.setFilter((m.filter + 1) % 4)
 			return m, nil
 
-		case "3":
-			m.filter = filterPaused
-			m.cursor = 0
-			m.table.SetCursor(0)
-			m.table.GotoTop()
+		case key.Matches(msg, m.defaultKeys.Filter1):
+			m.setFilter(filterAll)
 			return m, nil
 
-		case "4":
-			m.filter = filterDone
-			m.cursor = 0
-			m.table.SetCursor(0)
-			m.table.GotoTop()
+		case key.Matches(msg, m.defaultKeys.Filter2):
+			m.setFilter(filterActive)
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Filter3):
+			m.setFilter(filterPaused)
+			return m, nil
+
+		case key.Matches(msg, m.defaultKeys.Filter4):
+			m.setFilter(filterDone)
 			return m, nil
 
 		}
 
 	case tickMsg:
-		m.now = time.Time(msg)
-		return m, tea.Batch(tick(), fileWatchTick())
+		// Timers can change places as they finish (doneLast, sorts), so the
+		// cursor follows the selected timer rather than its row
+		visible := m.getVisibleTimers()
+		var selected *Timer
+		if m.cursor < len(visible) {
+			selected = &visible[m.cursor]
+		}
+
+		// Wall clock only, like nowFunc, so a tick after sleep sees the time that passed
+		m.now = time.Time(msg).Round(0)
+		// Notify before rolling so repeating timers announce each completion
+		cmds := append(m.notifyCompleted(false), tick(m.tickInterval()))
+		if m.watcher == nil {
+			cmds = append(cmds, fileWatchTick())
+		}
+		if rollRecurring(m.timers, m.now) {
+			m.dirty = true
+		}
+		if advanceChains(m.timers, m.now) {
+			m.dirty = true
+		}
+		if kept, n := removeExpiredDone(m.timers, m.now, m.durationConfig.autoDeleteAfter()); n > 0 {
+			m.replaceTimers(func() { m.timers = kept })
+			m.save()
+		}
+		m.applyQuietHours()
+		m.expireConfirm()
+		if selected == nil || !m.selectTimer(*selected) {
+			m.clampCursor()
+		}
+		return m, tea.Batch(cmds...)
 
 	case fileWatchMsg:
 		// Check if save file has been modified externally
@@ -482,11 +568,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if modTime.After(m.lastModTime) {
 				// File was modified externally, reload timers
 				if s, err := loadFromFile(); err == nil {
-					applySaveData(&m, s)
+					m.reloadTimers(s)
+					m.loadErr = nil
+					m.lastModTime = modTime
+				} else if unreadableSave(err) {
+					m.loadErr = err
 					m.lastModTime = modTime
 				}
 			}
 		}
+		if m.watcher != nil {
+			return m, waitForSaveFileChange(m.watcher)
+		}
 		return m, nil
 	}
 
@@ -499,34 +592,57 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // CLI functions are in cli.go
 
+// version is set at build time with -ldflags "-X main.version=v1.2.3"
+var version = "dev"
+
+// commandAliases maps command shortcuts to their full command names
+var commandAliases = map[string]string{
+	"a":  "add",
+	"l":  "list",
+	"p":  "pause",
+	"r":  "resume",
+	"d":  "delete",
+	"rs": "restart",
+	"e":  "edit",
+	"h":  "help",
+}
+
+// runTUI runs the interactive interface until the user quits
+func runTUI(m model) error {
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	final, err := p.Run()
+	saveOnExit(final)
+	return err
+}
+
 func main() {
-	// If no arguments provided (other than program name), run TUI
-	if len(os.Args) < 2 {
-		p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
+	// File overrides must be in place before anything is loaded
+	args, err := takeGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	// If no arguments provided (other than global flags), run TUI
+	if len(args) == 0 {
+		if err := runTUI(initialModel()); err != nil {
 			fmt.Println("error:", err)
 		}
 		return
 	}
 
 	// CLI mode: parse and execute commands
-	cmd := os.Args[1]
-	args := os.Args[2:]
-
-	// Command aliases
-	aliases := map[string]string{
-		"a":   "add",
-		"l":   "list",
-		"p":   "pause",
-		"r":   "resume",
-		"d":   "delete",
-		"rs":  "restart",
-		"e":   "edit",
-		"h":   "help",
+	cmd := args[0]
+	args = args[1:]
+
+	// Handled before any command so it works without a timers file
+	if cmd == "version" || cmd == "--version" || cmd == "-v" {
+		fmt.Println("go-countdown", version)
+		return
 	}
 
 	// Resolve alias
-	if fullCmd, ok := aliases[cmd]; ok {
+	if fullCmd, ok := commandAliases[cmd]; ok {
 		cmd = fullCmd
 	}
 
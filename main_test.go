@@ -0,0 +1,96 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testNow is the fixed time tests run at
+var testNow = time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+// useTempFiles points the timers and config files into a test directory and
+// fixes the clock at testNow, restoring both when the test ends
+func useTempFiles(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	oldSave, oldConfig, oldNow := saveFile, configFile, nowFunc
+	saveFile = filepath.Join(dir, "timers.json")
+	configFile = filepath.Join(dir, "config.json")
+	setClock(testNow)
+	t.Cleanup(func() {
+		saveFile, configFile, nowFunc = oldSave, oldConfig, oldNow
+	})
+}
+
+// setClock makes nowFunc return now
+func setClock(now time.Time) {
+	nowFunc = func() time.Time { return now }
+}
+
+// newTestModel saves timers and starts a TUI model on them
+func newTestModel(t *testing.T, timers []Timer) model {
+	t.Helper()
+	if err := saveTimers(timers); err != nil {
+		t.Fatal(err)
+	}
+	return initialModel()
+}
+
+// timerNames returns the names of timers in order
+func timerNames(timers []Timer) []string {
+	var names []string
+	for _, t := range timers {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := os.Stdout
+	os.Stdout = w
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- b
+	}()
+	fn()
+	os.Stdout = old
+	w.Close()
+	return string(<-done)
+}
+
+// writeConfig replaces the test config file with the given JSON
+func writeConfig(t *testing.T, config string) {
+	t.Helper()
+	if err := os.WriteFile(configFile, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// withStdin makes input the process stdin until the test ends
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = old
+		f.Close()
+	})
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// metricsLabelEscaper escapes label values for the Prometheus text format
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetrics writes timers in the Prometheus text exposition format: the
+// time left on each timer and the number of timers in each status
+func writeMetrics(w io.Writer, timers []Timer, now time.Time) error {
+	var b strings.Builder
+	b.WriteString("# HELP go_countdown_timer_remaining_seconds Time left on the timer.\n")
+	b.WriteString("# TYPE go_countdown_timer_remaining_seconds gauge\n")
+	counts := map[string]int{"active": 0, "paused": 0, "done": 0}
+	for _, t := range timers {
+		status := t.status(now)
+		counts[status]++
+		remaining := max(t.remainingAt(now), 0)
+		fmt.Fprintf(&b, "go_countdown_timer_remaining_seconds{id=\"%s\",name=\"%s\",status=\"%s\"} %d\n",
+			metricsLabelEscaper.Replace(t.ID), metricsLabelEscaper.Replace(t.Name), status,
+			int64(remaining.Round(time.Second).Seconds()))
+	}
+	b.WriteString("# HELP go_countdown_timers Number of timers in each status.\n")
+	b.WriteString("# TYPE go_countdown_timers gauge\n")
+	// Every status is written, so a series drops to 0 instead of vanishing
+	for _, status := range []string{"active", "paused", "done"} {
+		fmt.Fprintf(&b, "go_countdown_timers{status=\"%s\"} %d\n", status, counts[status])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sendNotification shows a desktop notification using the platform's own tools
+func sendNotification(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptQuote(body), appleScriptQuote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(10000, %s, %s, 'Info')
+Start-Sleep -Seconds 10
+$n.Dispose()`, powerShellQuote(title), powerShellQuote(body))
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=go-countdown", title, body)
+	}
+	return cmd.Run()
+}
+
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// playSound plays an audio file with the platform's command line player
+func playSound(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("afplay", path)
+	case "windows":
+		script := fmt.Sprintf("(New-Object Media.SoundPlayer %s).PlaySync()", powerShellQuote(path))
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	default:
+		// PulseAudio/PipeWire first, then plain ALSA
+		player := "paplay"
+		if _, err := exec.LookPath(player); err != nil {
+			player = "aplay"
+		}
+		cmd = exec.Command(player, path)
+	}
+	return cmd.Run()
+}
+
+// soundCmd rings the terminal bell, or plays soundFile when set and falls back
+// to the bell if it cannot be played
+func soundCmd(soundFile string) tea.Cmd {
+	return func() tea.Msg {
+		if soundFile == "" || playSound(soundFile) != nil {
+			_, _ = os.Stdout.WriteString("\a")
+		}
+		return nil
+	}
+}
+
+// notifyCmd sends the notification in the background so the UI never waits
+// on the notifier; failures are ignored since there is nowhere to show them
+func notifyCmd(title, body string) tea.Cmd {
+	return func() tea.Msg {
+		_ = sendNotification(title, body)
+		return nil
+	}
+}
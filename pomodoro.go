@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// newPomodoro builds a pomodoro session: rounds of "Work n" followed by
+// "Break n", without a break after the last round. The first timer runs and
+// the rest wait paused, each chained to start when the one before completes.
+func newPomodoro(work, brk time.Duration, rounds int, tags []string, now time.Time) []Timer {
+	tags = mergeTags(tags, []string{"pomodoro"})
+
+	var session []Timer
+	add := func(name string, d time.Duration) {
+		t := Timer{
+			ID:        newTimerID(),
+			Name:      name,
+			End:       now.Add(d),
+			Duration:  d,
+			Paused:    true,
+			Remaining: d,
+			Tags:      tags,
+			Created:   now,
+		}
+		if len(session) > 0 {
+			session[len(session)-1].Next = t.ID
+		}
+		session = append(session, t)
+	}
+	for i := 1; i <= rounds; i++ {
+		add(fmt.Sprintf("Work %d", i), work)
+		if i < rounds {
+			add(fmt.Sprintf("Break %d", i), brk)
+		}
+	}
+
+	session[0].resume(now)
+	return session
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdayMask has one bit per time.Weekday (bit 0 = Sunday)
+type weekdayMask uint8
+
+// weekdayLetters are the single-letter weekday codes, indexed by time.Weekday.
+// R is Thursday and U is Sunday so that every day has a unique letter.
+const weekdayLetters = "UMTWRFS"
+
+// parseWeekdays parses a spec like "MWF", "MTWRF" or "daily"
+func parseWeekdays(spec string) (weekdayMask, error) {
+	spec = strings.ToUpper(strings.TrimSpace(spec))
+	if spec == "DAILY" {
+		return 0x7f, nil
+	}
+	if spec == "" {
+		return 0, fmt.Errorf("empty weekday list")
+	}
+
+	var mask weekdayMask
+	for _, r := range spec {
+		i := strings.IndexRune(weekdayLetters, r)
+		if i < 0 {
+			return 0, fmt.Errorf("invalid weekday %q (use M T W R F S U, R = Thursday, U = Sunday)", r)
+		}
+		mask |= 1 << i
+	}
+	return mask, nil
+}
+
+func (w weekdayMask) has(day time.Weekday) bool {
+	return w&(1<<day) != 0
+}
+
+// String renders the mask Monday-first, e.g. "MWF"
+func (w weekdayMask) String() string {
+	var b strings.Builder
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		if w.has(day) {
+			b.WriteByte(weekdayLetters[day])
+		}
+	}
+	return b.String()
+}
+
+// parseTimeOfDay parses a 24-hour "HH:MM" clock time
+func parseTimeOfDay(s string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q (use HH:MM)", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// nextWeekdayOccurrence returns the first time after now that falls on one of
+// the weekdays in mask at the given clock time
+func nextWeekdayOccurrence(mask weekdayMask, timeOfDay string, now time.Time) (time.Time, error) {
+	if mask == 0 {
+		return time.Time{}, fmt.Errorf("no weekdays selected")
+	}
+	hour, minute, err := parseTimeOfDay(timeOfDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// A week and a day covers the case where today's slot has already passed
+	for d := 0; d <= 7; d++ {
+		day := now.AddDate(0, 0, d)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+		if mask.has(candidate.Weekday()) && candidate.After(now) {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no upcoming occurrence found")
+}
+
+// advanceChains starts the Next timer of every completed timer, counting from
+// the moment it completed so time missed while nothing ran is caught up. Each
+// link fires once. It reports whether any timer changed.
+func advanceChains(timers []Timer, now time.Time) bool {
+	byID := make(map[string]int, len(timers))
+	for i, t := range timers {
+		byID[t.ID] = i
+	}
+
+	changed := false
+	for again := true; again; {
+		again = false
+		for i := range timers {
+			t := &timers[i]
+			if t.Next == "" || t.Paused || t.End.After(now) {
+				continue
+			}
+			if j, ok := byID[t.Next]; ok {
+				timers[j].resume(t.End)
+			}
+			t.Next = ""
+			changed = true
+			// The started timer may itself be done already
+			again = true
+		}
+	}
+	return changed
+}
+
+// removeExpiredDone drops timers that have been done for longer than after
+// (0 keeps everything) and returns the rest with the number removed. Paused
+// timers are never done, so they always stay.
+func removeExpiredDone(timers []Timer, now time.Time, after time.Duration) ([]Timer, int) {
+	if after <= 0 {
+		return timers, 0
+	}
+	kept := make([]Timer, 0, len(timers))
+	for _, t := range timers {
+		if !t.Paused && t.remainingAt(now) <= 0 && t.doneFor(now) > after {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept, len(timers) - len(kept)
+}
+
+// rollRecurring moves completed repeating and weekday-scheduled timers to
+// their next occurrence. It reports whether any timer changed.
+func rollRecurring(timers []Timer, now time.Time) bool {
+	changed := false
+	for i := range timers {
+		t := &timers[i]
+		if t.Paused || t.End.After(now) {
+			continue
+		}
+		if t.Repeat > 0 {
+			// Skip intervals missed while nothing was running
+			interval := max(t.toRealTime(t.Repeat), time.Second)
+			missed := now.Sub(t.End) / interval
+			t.Started = t.End.Add(missed * interval)
+			t.End = t.End.Add((missed + 1) * interval)
+			t.Duration = t.Repeat
+			t.Notified = false
+			changed = true
+			continue
+		}
+		if t.Weekdays == 0 {
+			continue
+		}
+		next, err := nextWeekdayOccurrence(t.Weekdays, t.TimeOfDay, now)
+		if err != nil {
+			continue
+		}
+		t.Started = now
+		t.End = next
+		t.Duration = next.Sub(now)
+		t.Notified = false
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sequencePanelHeight is the number of lines renderSequence takes
+const sequencePanelHeight = 3
+
+// renderSequence draws the timers sharing the selected timer's first tag as
+// one timeline, in saved order: each segment is as wide as its share of the
+// total duration, done segments are full, and the current one (the first not
+// done) fills as it runs
+func renderSequence(m model) string {
+	indent := strings.Repeat(" ", 21) // line up with the table cells
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	tag := ""
+	if visible := m.getVisibleTimers(); m.cursor < len(visible) && len(visible[m.cursor].Tags) > 0 {
+		tag = visible[m.cursor].Tags[0]
+	}
+	var group []Timer
+	for _, t := range m.timers {
+		if t.Duration > 0 && slices.Contains(t.Tags, tag) {
+			group = append(group, t)
+		}
+	}
+	if len(group) == 0 {
+		return indent + hintStyle.Render("Select a tagged timer to see its sequence") + "\n\n"
+	}
+
+	// One column between segments, at least one column per segment
+	width := max(m.table.Width()-2, 1)
+	group = group[:min(len(group), (width+1)/2)]
+	durations := make([]time.Duration, len(group))
+	for i, t := range group {
+		durations[i] = t.Duration
+	}
+	widths := segmentWidths(durations, width-(len(group)-1))
+
+	current := -1
+	for i, t := range group {
+		if t.Paused || t.End.After(m.now) {
+			current = i
+			break
+		}
+	}
+
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	currentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	upcomingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	var bar, labels []string
+	for i, t := range group {
+		w := widths[i]
+		label := padRight(truncateWidth(t.Name, w), w)
+		switch {
+		case current < 0 || i < current:
+			bar = append(bar, doneStyle.Render(strings.Repeat("█", w)))
+			labels = append(labels, doneStyle.Render(label))
+		case i == current:
+			bar = append(bar, currentStyle.Render(segmentProgress(t, m.now, w)))
+			labels = append(labels, currentStyle.Render(label))
+		default:
+			bar = append(bar, upcomingStyle.Render(strings.Repeat("░", w)))
+			labels = append(labels, upcomingStyle.Render(label))
+		}
+	}
+
+	header := fmt.Sprintf("#%s: all done", tag)
+	if current >= 0 {
+		header = fmt.Sprintf("#%s  now: %s %s", tag, group[current].Name, group[current].StatusText(m.now))
+		if current+1 < len(group) {
+			header += fmt.Sprintf(" · next: %s (%s)", group[current+1].Name, formatDuration(group[current+1].Duration))
+		}
+	}
+	return indent + hintStyle.Render(truncateWidth(header, width)) + "\n" +
+		indent + strings.Join(bar, " ") + "\n" +
+		indent + strings.Join(labels, " ")
+}
+
+// segmentProgress draws the current timer's segment, filled as far as it has
+// counted down
+func segmentProgress(t Timer, now time.Time, width int) string {
+	remaining := t.Remaining
+	if !t.Paused {
+		remaining = t.End.Sub(now)
+	}
+	filled := int(float64(width) * (1 - float64(max(remaining, 0))/float64(t.Duration)))
+	filled = min(max(filled, 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("▒", width-filled)
+}
+
+// segmentWidths splits width columns between segments in proportion to their
+// durations, giving each at least one column and the rounding leftovers to
+// the earliest segments
+func segmentWidths(durations []time.Duration, width int) []int {
+	widths := make([]int, len(durations))
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	if len(durations) == 0 || total <= 0 {
+		return widths
+	}
+	used := 0
+	for i, d := range durations {
+		widths[i] = max(int(float64(width)*float64(d)/float64(total)), 1)
+		used += widths[i]
+	}
+	for i := 0; used < width; i = (i + 1) % len(widths) {
+		widths[i]++
+		used++
+	}
+	// Minimum widths can overshoot; take the excess from the widest segments
+	for used > width {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] == 1 {
+			break
+		}
+		widths[widest]--
+		used--
+	}
+	return widths
+}
+
+// truncateWidth shortens s to width display columns, ending in "…" when cut
+func truncateWidth(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if lipgloss.Width(b.String()+string(r)) >= width {
+			break
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + "…"
+}
+
+// padRight pads s with spaces to width display columns
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// timerServer exposes the timers file as a small JSON API. Each request
+// loads, changes and saves the file while holding mu, so concurrent requests
+// can't overwrite each other's changes.
+type timerServer struct {
+	mu sync.Mutex
+}
+
+// apiError is an error reported to the client with an HTTP status
+type apiError struct {
+	status int
+	msg    string
+}
+
+func (e apiError) Error() string { return e.msg }
+
+// addRequest is the body of POST /timers
+type addRequest struct {
+	Name     string   `json:"name"`
+	Duration string   `json:"duration"`
+	Tags     []string `json:"tags"`
+	Note     string   `json:"note"`
+	Paused   bool     `json:"paused"`
+}
+
+// runServer serves the timer API on host:port until the process is stopped
+func runServer(host, port string) error {
+	addr := net.JoinHostPort(host, port)
+	s := &timerServer{}
+	infof("Serving timers on http://%s (Ctrl+C to stop)\n", addr)
+	return http.ListenAndServe(addr, s.routes())
+}
+
+func (s *timerServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /timers", s.handleList)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("POST /timers", s.handleAdd)
+	mux.HandleFunc("POST /timers/{id}/pause", s.handleTimer(func(t *Timer, now time.Time) error {
+		if !t.pause(now) && !t.Paused {
+			return apiError{http.StatusConflict, "cannot pause: timer already done"}
+		}
+		return nil
+	}))
+	mux.HandleFunc("POST /timers/{id}/resume", s.handleTimer(func(t *Timer, now time.Time) error {
+		if !t.resume(now) && t.Paused {
+			return apiError{http.StatusConflict, "cannot resume: no remaining time"}
+		}
+		return nil
+	}))
+	mux.HandleFunc("POST /timers/{id}/restart", s.handleTimer(func(t *Timer, now time.Time) error {
+		if t.Duration <= 0 {
+			return apiError{http.StatusConflict, "cannot restart: timer has no duration"}
+		}
+		t.restart(now, false)
+		return nil
+	}))
+	mux.HandleFunc("DELETE /timers/{id}", s.handleDelete)
+	return mux
+}
+
+// update loads the timers, lets fn change them and saves the result. Like the
+// CLI, completed schedules and chains move on first.
+func (s *timerServer) update(fn func(timers []Timer, now time.Time) ([]Timer, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timers, err := loadTimers()
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error loading timers: %w", err)
+	}
+	now := nowFunc()
+	rollRecurring(timers, now)
+	advanceChains(timers, now)
+	if timers, err = fn(timers, now); err != nil {
+		return err
+	}
+	if err := saveTimers(timers); err != nil {
+		return fmt.Errorf("error saving timers: %w", err)
+	}
+	return nil
+}
+
+func (s *timerServer) handleList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timers, err := loadTimers()
+	if err != nil && !os.IsNotExist(err) {
+		writeAPIError(w, fmt.Errorf("error loading timers: %w", err))
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, timersJSON(timers, r.URL.Query().Get("filter"), sortManual, false))
+}
+
+func (s *timerServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timers, err := loadTimers()
+	if err != nil && !os.IsNotExist(err) {
+		writeAPIError(w, fmt.Errorf("error loading timers: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_ = writeMetrics(w, timers, nowFunc())
+}
+
+func (s *timerServer) handleAdd(w http.ResponseWriter, r *http.Request) {
+	var req addRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, apiError{http.StatusBadRequest, "invalid JSON body: " + err.Error()})
+		return
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		cfg = defaultConfig()
+	}
+	if req.Duration == "" {
+		req.Duration = cfg.DefaultDuration
+	}
+	if req.Name == "" {
+		writeAPIError(w, apiError{http.StatusBadRequest, "missing name"})
+		return
+	}
+	d, err := parseDuration(req.Duration)
+	if err != nil {
+		writeAPIError(w, apiError{http.StatusBadRequest, "invalid duration: " + err.Error()})
+		return
+	}
+
+	var added Timer
+	err = s.update(func(timers []Timer, now time.Time) ([]Timer, error) {
+		added = Timer{
+			ID:       newTimerID(),
+			Name:     req.Name,
+			End:      now.Add(d),
+			Duration: d,
+			Created:  now,
+			Started:  now,
+			Tags:     mergeTags(cfg.newTimerTags(), req.Tags),
+			Note:     req.Note,
+		}
+		if req.Paused {
+			// Resuming starts the clock from the full duration
+			added.Paused = true
+			added.Remaining = d
+			added.Started = time.Time{}
+		}
+		return append(timers, added), nil
+	})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, newTimerJSON(added, nowFunc()))
+}
+
+// handleTimer returns a handler that applies fn to the timer named by the
+// {id} path value and responds with the changed timer
+func (s *timerServer) handleTimer(fn func(t *Timer, now time.Time) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		var changed Timer
+		err := s.update(func(timers []Timer, now time.Time) ([]Timer, error) {
+			i := timerIndexByID(timers, id)
+			if i < 0 {
+				return nil, apiError{http.StatusNotFound, "no timer with id " + id}
+			}
+			if err := fn(&timers[i], now); err != nil {
+				return nil, err
+			}
+			changed = timers[i]
+			return timers, nil
+		})
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, newTimerJSON(changed, nowFunc()))
+	}
+}
+
+func (s *timerServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	err := s.update(func(timers []Timer, now time.Time) ([]Timer, error) {
+		i := timerIndexByID(timers, id)
+		if i < 0 {
+			return nil, apiError{http.StatusNotFound, "no timer with id " + id}
+		}
+		return append(timers[:i], timers[i+1:]...), nil
+	})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// timerIndexByID returns the index of the timer with the given ID, or -1
+func timerIndexByID(timers []Timer, id string) int {
+	for i, t := range timers {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeAPIError responds with {"error": "..."}, using the status of an
+// apiError and 500 for anything else
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var apiErr apiError
+	if errors.As(err, &apiErr) {
+		status = apiErr.status
+	}
+	writeAPIJSON(w, status, map[string]string{"error": err.Error()})
+}
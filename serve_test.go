@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveTimers saves timers and returns the API handler serving them
+func serveTimers(t *testing.T, timers []Timer) http.Handler {
+	t.Helper()
+	useTempFiles(t)
+	if err := saveTimers(timers); err != nil {
+		t.Fatal(err)
+	}
+	return (&timerServer{}).routes()
+}
+
+// request sends a request to h and returns the recorded response
+func request(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+// apiTimers returns an active, a paused and a done timer with known IDs
+func apiTimers() []Timer {
+	return []Timer{
+		{ID: "active", Name: "Active", Duration: time.Hour, Started: testNow, End: testNow.Add(time.Hour)},
+		{ID: "paused", Name: "Paused", Duration: time.Hour, Paused: true, Remaining: 20 * time.Minute},
+		{ID: "done", Name: "Done", Duration: time.Minute, Started: testNow.Add(-2 * time.Minute), End: testNow.Add(-time.Minute)},
+	}
+}
+
+func TestServeList(t *testing.T) {
+	h := serveTimers(t, apiTimers())
+
+	rec := request(h, "GET", "/timers", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /timers = %d, want 200", rec.Code)
+	}
+	var got []timerJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].ID != "active" || got[0].RemainingSeconds != 3600 || got[1].Status != "paused" {
+		t.Errorf("GET /timers = %+v", got)
+	}
+
+	rec = request(h, "GET", "/timers?filter=--done", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "done" {
+		t.Errorf("GET /timers?filter=--done = %+v, want only the done timer", got)
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	h := serveTimers(t, apiTimers())
+
+	rec := request(h, "GET", "/metrics", "")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("GET /metrics = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	for _, want := range []string{
+		`go_countdown_timer_remaining_seconds{id="active",name="Active",status="active"} 3600`,
+		`go_countdown_timers{status="paused"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("metrics missing %s:\n%s", want, rec.Body)
+		}
+	}
+}
+
+func TestServeAdd(t *testing.T) {
+	h := serveTimers(t, nil)
+
+	rec := request(h, "POST", "/timers", `{"name": "tea", "duration": "3m", "tags": ["kitchen"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /timers = %d %s, want 201", rec.Code, rec.Body)
+	}
+	var added timerJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &added); err != nil {
+		t.Fatal(err)
+	}
+	if added.Name != "tea" || added.RemainingSeconds != 180 || added.ID == "" {
+		t.Errorf("added %+v", added)
+	}
+	timers, _ := loadTimers()
+	if len(timers) != 1 || timers[0].ID != added.ID || !timers[0].End.Equal(testNow.Add(3*time.Minute)) {
+		t.Errorf("saved %+v", timers)
+	}
+
+	rec = request(h, "POST", "/timers", `{"name": "later", "duration": "10m", "paused": true}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST paused = %d, want 201", rec.Code)
+	}
+	if timers, _ := loadTimers(); len(timers) != 2 || !timers[1].Paused || timers[1].Remaining != 10*time.Minute {
+		t.Errorf("paused add saved %+v", timers)
+	}
+}
+
+func TestServeAddInvalid(t *testing.T) {
+	h := serveTimers(t, nil)
+	for _, body := range []string{
+		`{"name": "tea"`,
+		`{"duration": "3m"}`,
+		`{"name": "tea", "duration": "3x"}`,
+	} {
+		rec := request(h, "POST", "/timers", body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("POST %s = %d, want 400", body, rec.Code)
+		}
+		var e map[string]string
+		if json.Unmarshal(rec.Body.Bytes(), &e) != nil || e["error"] == "" {
+			t.Errorf("POST %s body = %s, want an error message", body, rec.Body)
+		}
+	}
+	if timers, _ := loadTimers(); len(timers) != 0 {
+		t.Errorf("invalid adds saved %+v", timers)
+	}
+}
+
+func TestServeTimerActions(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{"POST", "/timers/active/pause", http.StatusOK},
+		{"POST", "/timers/paused/pause", http.StatusOK}, // already paused
+		{"POST", "/timers/done/pause", http.StatusConflict},
+		{"POST", "/timers/paused/resume", http.StatusOK},
+		{"POST", "/timers/active/resume", http.StatusOK}, // already running
+		{"POST", "/timers/done/restart", http.StatusOK},
+		{"DELETE", "/timers/done", http.StatusNoContent},
+		{"POST", "/timers/missing/pause", http.StatusNotFound},
+		{"POST", "/timers/missing/resume", http.StatusNotFound},
+		{"POST", "/timers/missing/restart", http.StatusNotFound},
+		{"DELETE", "/timers/missing", http.StatusNotFound},
+		{"GET", "/timers/active/pause", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		h := serveTimers(t, apiTimers())
+		if rec := request(h, tt.method, tt.path, ""); rec.Code != tt.want {
+			t.Errorf("%s %s = %d %s, want %d", tt.method, tt.path, rec.Code, rec.Body, tt.want)
+		}
+	}
+}
+
+func TestServeTimerActionsSave(t *testing.T) {
+	h := serveTimers(t, apiTimers())
+
+	request(h, "POST", "/timers/active/pause", "")
+	request(h, "POST", "/timers/paused/resume", "")
+	request(h, "POST", "/timers/done/restart", "")
+	timers, _ := loadTimers()
+	if !timers[0].Paused || timers[0].Remaining != time.Hour {
+		t.Errorf("paused timer saved as %+v", timers[0])
+	}
+	if timers[1].Paused || !timers[1].End.Equal(testNow.Add(20*time.Minute)) {
+		t.Errorf("resumed timer saved as %+v", timers[1])
+	}
+	if timers[2].status(testNow) != "active" || !timers[2].End.Equal(testNow.Add(time.Minute)) {
+		t.Errorf("restarted timer saved as %+v", timers[2])
+	}
+
+	request(h, "DELETE", "/timers/paused", "")
+	if timers, _ := loadTimers(); len(timers) != 2 || timerIndexByID(timers, "paused") >= 0 {
+		t.Errorf("after DELETE the timers are %v", timerNames(timers))
+	}
+}
+
+func TestServeRestartWithoutDuration(t *testing.T) {
+	h := serveTimers(t, []Timer{{ID: "bare", Name: "Bare", End: testNow.Add(-time.Minute)}})
+	if rec := request(h, "POST", "/timers/bare/restart", ""); rec.Code != http.StatusConflict {
+		t.Errorf("restart without a duration = %d, want 409", rec.Code)
+	}
+}
+
+func TestServeResumeWithoutRemaining(t *testing.T) {
+	h := serveTimers(t, []Timer{{ID: "empty", Name: "Empty", Duration: time.Minute, Paused: true}})
+	if rec := request(h, "POST", "/timers/empty/resume", ""); rec.Code != http.StatusConflict {
+		t.Errorf("resume with no remaining time = %d, want 409", rec.Code)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timerSort selects the order timers are shown in
+type timerSort int
+
+const (
+	sortManual    timerSort = iota // saved order: creation order unless reordered
+	sortName                       // alphabetical, case-insensitive
+	sortRemaining                  // least time left first; paused timers use their frozen remaining
+	sortEnd                        // earliest end time first
+	sortDuration                   // shortest full duration first
+	sortCreated                    // oldest first; timers without a creation time count as oldest
+)
+
+// tuiSorts are the orders the TUI sort key cycles through; duration is CLI only
+var tuiSorts = []timerSort{sortManual, sortName, sortRemaining, sortEnd, sortCreated}
+
+// next returns the order after s in the TUI sort cycle
+func (s timerSort) next() timerSort {
+	i := slices.Index(tuiSorts, s)
+	return tuiSorts[(i+1)%len(tuiSorts)]
+}
+
+// parseTimerSort parses a sort key as used by "list --sort"
+func parseTimerSort(s string) (timerSort, error) {
+	for _, mode := range []timerSort{sortManual, sortName, sortRemaining, sortEnd, sortDuration, sortCreated} {
+		if strings.ToLower(s) == mode.String() {
+			return mode, nil
+		}
+	}
+	return sortManual, fmt.Errorf("invalid sort %q (use name, remaining, end, duration or created)", s)
+}
+
+func (s timerSort) String() string {
+	switch s {
+	case sortName:
+		return "name"
+	case sortRemaining:
+		return "remaining"
+	case sortEnd:
+		return "end"
+	case sortDuration:
+		return "duration"
+	case sortCreated:
+		return "created"
+	default:
+		return "manual"
+	}
+}
+
+// sortTimers orders timers in place. The sort is stable so ties keep their
+// saved order, and sortManual leaves the slice untouched unless reversed.
+func sortTimers(timers []Timer, mode timerSort, reverse bool, now time.Time) {
+	less := func(a, b Timer) bool { return false }
+	switch mode {
+	case sortName:
+		less = func(a, b Timer) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case sortRemaining:
+		less = func(a, b Timer) bool { return a.remainingAt(now) < b.remainingAt(now) }
+	case sortEnd:
+		less = func(a, b Timer) bool { return a.End.Before(b.End) }
+	case sortDuration:
+		less = func(a, b Timer) bool { return a.Duration < b.Duration }
+	case sortCreated:
+		less = func(a, b Timer) bool { return a.Created.Before(b.Created) }
+	}
+
+	sort.SliceStable(timers, func(i, j int) bool {
+		return less(timers[i], timers[j])
+	})
+	if reverse {
+		for i, j := 0, len(timers)-1; i < j; i, j = i+1, j-1 {
+			timers[i], timers[j] = timers[j], timers[i]
+		}
+	}
+}
+
+// partitionDone moves done timers after the rest, keeping the order within
+// each group
+func partitionDone(timers []Timer, now time.Time) {
+	sort.SliceStable(timers, func(i, j int) bool {
+		return timers[i].status(now) != "done" && timers[j].status(now) == "done"
+	})
+}
+
+// partitionPinned moves pinned timers before the rest, keeping the order
+// within each group
+func partitionPinned(timers []Timer) {
+	sort.SliceStable(timers, func(i, j int) bool {
+		return timers[i].Pinned && !timers[j].Pinned
+	})
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortFixture mixes active, paused and done timers so every key orders them
+// differently
+func sortFixture() []Timer {
+	return []Timer{
+		{ID: "1", Name: "bravo", End: testNow.Add(2 * time.Hour), Duration: 3 * time.Hour, Created: testNow.Add(-time.Hour)},
+		{ID: "2", Name: "Alpha", Paused: true, Remaining: 10 * time.Minute, End: testNow.Add(-2 * time.Hour), Duration: time.Hour, Created: testNow.Add(-3 * time.Hour)},
+		{ID: "3", Name: "delta", End: testNow.Add(-time.Minute), Duration: 2 * time.Hour, Created: testNow.Add(-2 * time.Hour)},
+		{ID: "4", Name: "charlie", End: testNow.Add(30 * time.Minute), Duration: 30 * time.Minute},
+	}
+}
+
+func TestSortTimers(t *testing.T) {
+	tests := []struct {
+		mode timerSort
+		want []string
+	}{
+		{sortManual, []string{"bravo", "Alpha", "delta", "charlie"}},
+		{sortName, []string{"Alpha", "bravo", "charlie", "delta"}},      // case-insensitive
+		{sortRemaining, []string{"delta", "Alpha", "charlie", "bravo"}}, // done, paused 10m, 30m, 2h
+		{sortEnd, []string{"Alpha", "delta", "charlie", "bravo"}},       // by stored end
+		{sortDuration, []string{"charlie", "Alpha", "delta", "bravo"}},  // 30m, 1h, 2h, 3h
+		{sortCreated, []string{"charlie", "Alpha", "delta", "bravo"}},   // unknown counts as oldest
+	}
+	for _, tt := range tests {
+		timers := sortFixture()
+		sortTimers(timers, tt.mode, false, testNow)
+		if got := timerNames(timers); !slices.Equal(got, tt.want) {
+			t.Errorf("sort %s = %v, want %v", tt.mode, got, tt.want)
+		}
+
+		timers = sortFixture()
+		sortTimers(timers, tt.mode, true, testNow)
+		reversed := slices.Clone(tt.want)
+		slices.Reverse(reversed)
+		if got := timerNames(timers); !slices.Equal(got, reversed) {
+			t.Errorf("sort %s reversed = %v, want %v", tt.mode, got, reversed)
+		}
+	}
+}
+
+func TestSortTimersKeepsTiesInSavedOrder(t *testing.T) {
+	timers := []Timer{
+		{ID: "1", Name: "first", Duration: time.Hour},
+		{ID: "2", Name: "second", Duration: time.Hour},
+		{ID: "3", Name: "short", Duration: time.Minute},
+	}
+	sortTimers(timers, sortDuration, false, testNow)
+	if got := timerNames(timers); !slices.Equal(got, []string{"short", "first", "second"}) {
+		t.Errorf("got %v, want ties in saved order", got)
+	}
+}
+
+func TestParseTimerSort(t *testing.T) {
+	for _, mode := range []timerSort{sortName, sortRemaining, sortEnd, sortDuration, sortCreated} {
+		if got, err := parseTimerSort(mode.String()); err != nil || got != mode {
+			t.Errorf("parseTimerSort(%q) = %v, %v", mode.String(), got, err)
+		}
+	}
+	if _, err := parseTimerSort("size"); err == nil {
+		t.Error("parseTimerSort(\"size\") succeeded, want error")
+	}
+}
+
+func TestListSortFlag(t *testing.T) {
+	useTempFiles(t)
+	if err := saveTimers(sortFixture()); err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(t, func() {
+		if err := executeCLICommand("list", []string{"--sort=remaining", "--reverse", "--compact"}); err != nil {
+			t.Error(err)
+		}
+	})
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		names = append(names, strings.Fields(line)[0])
+	}
+	if want := []string{"bravo", "charlie", "Alpha", "delta"}; !slices.Equal(names, want) {
+		t.Errorf("list --sort=remaining --reverse = %v, want %v", names, want)
+	}
+}
+
+func TestSortKeyCyclesToCreated(t *testing.T) {
+	useTempFiles(t)
+	m := newTestModel(t, sortFixture())
+	var modes []timerSort
+	for range len(tuiSorts) {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		m = next.(model)
+		modes = append(modes, m.sortMode)
+	}
+	if want := []timerSort{sortName, sortRemaining, sortEnd, sortCreated, sortManual}; !slices.Equal(modes, want) {
+		t.Errorf("s cycles through %v, want %v", modes, want)
+	}
+}
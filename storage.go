@@ -2,23 +2,106 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 )
 
 var saveFile string
 
+// errCorruptSave marks a timers file that exists but cannot be parsed
+var errCorruptSave = errors.New("timers file is corrupt")
+
+// errNewerSave marks a timers file written by a newer go-countdown, which may
+// hold fields this version would drop on save
+var errNewerSave = errors.New("timers file is from a newer version")
+
+// unreadableSave reports whether err means the timers file exists but must
+// not be overwritten
+func unreadableSave(err error) bool {
+	return errors.Is(err, errCorruptSave) || errors.Is(err, errNewerSave)
+}
+
+// dataFileEnv overrides the timers file location, like the --data-file flag
+const dataFileEnv = "GO_COUNTDOWN_DATA"
+
 func init() {
-	homeDir, err := os.UserHomeDir()
+	saveFile = defaultSaveFile()
+}
+
+// defaultSaveFile returns the timers file used without --data-file or
+// --profile: $GO_COUNTDOWN_DATA, or timers.json in the config directory
+func defaultSaveFile() string {
+	if path := os.Getenv(dataFileEnv); path != "" {
+		return path
+	}
+	return filepath.Join(appDir(), "timers.json")
+}
+
+// defaultProfile names the timers.json set used when no --profile is given
+const defaultProfile = "default"
+
+// profileFile returns the timers file for a profile: timers-<name>.json next
+// to the default timers.json
+func profileFile(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid profile name: %q", name)
+	}
+	if name == defaultProfile {
+		return filepath.Join(appDir(), "timers.json"), nil
+	}
+	return filepath.Join(appDir(), "timers-"+name+".json"), nil
+}
+
+// listProfiles returns the profiles with a timers file in the config directory
+func listProfiles() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(appDir(), "timers*.json"))
 	if err != nil {
-		saveFile = "timers.json"
-	} else {
-		saveFile = filepath.Join(homeDir, ".config", "go-countdown", "timers.json")
+		return nil, err
+	}
+	var profiles []string
+	for _, f := range files {
+		base := strings.TrimSuffix(filepath.Base(f), ".json")
+		if base == "timers" {
+			profiles = append(profiles, defaultProfile)
+		} else if name, ok := strings.CutPrefix(base, "timers-"); ok && name != "" {
+			profiles = append(profiles, name)
+		}
 	}
+	sort.Strings(profiles)
+	return profiles, nil
 }
 
+// saveVersion is the current timers file format. Files without a version
+// predate it and are upgraded by migrateSave.
+const saveVersion = 2
+
 type saveData struct {
-	Timers []Timer `json:"timers"`
+	Version int     `json:"version"`
+	Timers  []Timer `json:"timers"`
+}
+
+// migrateSave upgrades s from its version to saveVersion one step at a time
+func migrateSave(s *saveData) {
+	if s.Version < 1 {
+		// v0 files could lack IDs; assigning them here persists them on rewrite
+		assignMissingIDs(s.Timers)
+	}
+	if s.Version < 2 {
+		// Best guess at when timers saved before Created existed were added.
+		// A paused timer's End is stale, so paused ones keep a zero Created.
+		for i := range s.Timers {
+			t := &s.Timers[i]
+			if t.Created.IsZero() && t.Duration > 0 && !t.Paused {
+				t.Created = t.End.Add(-t.toRealTime(t.Duration))
+			}
+		}
+	}
+	s.Version = saveVersion
 }
 
 func applySaveData(m *model, s saveData) {
@@ -26,6 +109,10 @@ func applySaveData(m *model, s saveData) {
 }
 
 func saveToFile(m model) error {
+	// Never overwrite a file we could not read; it may only have a typo
+	if m.loadErr != nil {
+		return m.loadErr
+	}
 	return saveTimers(m.timers)
 }
 
@@ -36,7 +123,7 @@ func loadFromFile() (saveData, error) {
 
 // saveTimers saves timers directly (for CLI use)
 func saveTimers(timers []Timer) error {
-	data := saveData{Timers: timers}
+	data := saveData{Version: saveVersion, Timers: timers}
 
 	b, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -52,17 +139,73 @@ func saveTimers(timers []Timer) error {
 
 // loadTimers loads timers directly (for CLI use)
 func loadTimers() ([]Timer, error) {
-	var s saveData
-
-	b, err := os.ReadFile(saveFile)
+	s, err := readSaveFile(saveFile)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(b, &s)
+	if s.Version < saveVersion {
+		migrateSave(&s)
+		// Rewrite at the current version; if that fails it migrates again next load
+		_ = saveTimers(s.Timers)
+	}
+	assignMissingIDs(s.Timers)
+	return s.Timers, nil
+}
+
+// readSaveFile reads timers saved in the timers.json format from path
+func readSaveFile(path string) (saveData, error) {
+	var s saveData
+
+	b, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return s, err
 	}
 
-	return s.Timers, nil
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("%w: %v", errCorruptSave, err)
+	}
+	if s.Version > saveVersion {
+		return s, fmt.Errorf("%w (%d, this one reads up to %d); upgrade go-countdown", errNewerSave, s.Version, saveVersion)
+	}
+	return s, nil
+}
+
+// backupCorruptSave moves an unreadable timers file aside so a fresh one can
+// be started, and returns the backup path
+func backupCorruptSave() (string, error) {
+	backup := fmt.Sprintf("%s.corrupt-%s", saveFile, time.Now().Format("20060102-150405"))
+	return backup, os.Rename(saveFile, backup)
+}
+
+// uiStateData holds TUI choices that carry over between runs
+type uiStateData struct {
+	Compact bool `json:"compact"`
+}
+
+// uiStateFile returns where the TUI state is kept: ui.json next to the config
+func uiStateFile() string {
+	return filepath.Join(filepath.Dir(configFile), "ui.json")
+}
+
+// loadUIState reads the saved TUI state; a missing or unreadable file gives
+// the defaults
+func loadUIState() uiStateData {
+	var s uiStateData
+	if b, err := os.ReadFile(uiStateFile()); err == nil {
+		_ = json.Unmarshal(b, &s)
+	}
+	return s
+}
+
+// saveUIState writes the TUI state for the next run
+func saveUIState(s uiStateData) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(uiStateFile()), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(uiStateFile(), b, 0o644)
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadMigratesVersion0(t *testing.T) {
+	useTempFiles(t)
+	// Before versions, IDs and Created existed
+	v0 := `{"timers": [{"name": "tea", "end": "2026-03-10T12:05:00Z", "duration": 300000000000}]}`
+	if err := os.WriteFile(saveFile, []byte(v0), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	timers, err := loadTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timers) != 1 || timers[0].ID == "" {
+		t.Fatalf("loadTimers() = %+v, want one timer with an ID", timers)
+	}
+	if want := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC); !timers[0].Created.Equal(want) {
+		t.Errorf("Created = %v, want %v", timers[0].Created, want)
+	}
+
+	// The file is rewritten at the current version with the new ID
+	s, err := readSaveFile(saveFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Version != saveVersion {
+		t.Errorf("rewritten version = %d, want %d", s.Version, saveVersion)
+	}
+	if len(s.Timers) != 1 || s.Timers[0].ID != timers[0].ID {
+		t.Errorf("rewritten timers = %+v, want the ID %q kept", s.Timers, timers[0].ID)
+	}
+}
+
+func TestLoadMigratesVersion1(t *testing.T) {
+	useTempFiles(t)
+	v1 := `{"version": 1, "timers": [{"id": "a", "name": "tea", "end": "2026-03-10T12:05:00Z", "duration": 300000000000}]}`
+	if err := os.WriteFile(saveFile, []byte(v1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	timers, err := loadTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timers) != 1 || timers[0].ID != "a" {
+		t.Fatalf("loadTimers() = %+v, want timer a kept", timers)
+	}
+	if timers[0].Created.IsZero() {
+		t.Error("Created was not filled in for a v1 timer")
+	}
+}
+
+func TestLoadMigratesPausedVersion1(t *testing.T) {
+	useTempFiles(t)
+	// A paused timer's end was set when it last ran, so it dates nothing
+	v1 := `{"version": 1, "timers": [{"id": "a", "name": "tea", "end": "2026-03-01T09:00:00Z", "duration": 300000000000, "paused": true, "remaining": 120000000000}]}`
+	if err := os.WriteFile(saveFile, []byte(v1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	timers, err := loadTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timers) != 1 || !timers[0].Paused {
+		t.Fatalf("loadTimers() = %+v, want paused timer a kept", timers)
+	}
+	if !timers[0].Created.IsZero() {
+		t.Errorf("Created = %v for a paused v1 timer, want it left unset", timers[0].Created)
+	}
+}
+
+func TestLoadRefusesNewerVersion(t *testing.T) {
+	useTempFiles(t)
+	newer, _ := json.Marshal(saveData{Version: saveVersion + 1, Timers: []Timer{{ID: "a", Name: "tea"}}})
+	if err := os.WriteFile(saveFile, newer, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadTimers(); !errors.Is(err, errNewerSave) {
+		t.Errorf("loadTimers() error = %v, want errNewerSave", err)
+	}
+	// The file is left alone for the newer version
+	if b, _ := os.ReadFile(saveFile); string(b) != string(newer) {
+		t.Errorf("newer file was rewritten:\n%s", b)
+	}
+}
+
+func TestLoadCorruptSave(t *testing.T) {
+	useTempFiles(t)
+	if err := os.WriteFile(saveFile, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTimers(); !errors.Is(err, errCorruptSave) {
+		t.Errorf("loadTimers() error = %v, want errCorruptSave", err)
+	}
+}
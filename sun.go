@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	julianUnixEpoch = 2440587.5 // Julian date of 1970-01-01T00:00Z
+	julianJ2000     = 2451545.0 // Julian date of 2000-01-01T12:00Z
+)
+
+func toJulian(t time.Time) float64 {
+	return float64(t.Unix())/86400 + julianUnixEpoch
+}
+
+func fromJulian(j float64) time.Time {
+	return time.Unix(int64(math.Round((j-julianUnixEpoch)*86400)), 0)
+}
+
+func sinDeg(x float64) float64 { return math.Sin(x * math.Pi / 180) }
+func cosDeg(x float64) float64 { return math.Cos(x * math.Pi / 180) }
+
+// sunTimes computes sunrise and sunset for the calendar day of date at the given
+// coordinates (degrees, north and east positive) using the sunrise equation.
+// Results are accurate to about a minute, which is plenty for reminders.
+func sunTimes(date time.Time, lat, lon float64) (sunrise, sunset time.Time, err error) {
+	// The day number counts from the calendar date at 0h UTC; taking it from
+	// local noon would land on the next day west of Greenwich
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Mean solar time for this day and longitude
+	n := math.Ceil(toJulian(day) - julianJ2000 + 0.0008)
+	meanSolar := n - lon/360
+
+	// Solar mean anomaly, equation of the center and ecliptic longitude
+	anomaly := math.Mod(357.5291+0.98560028*meanSolar, 360)
+	center := 1.9148*sinDeg(anomaly) + 0.0200*sinDeg(2*anomaly) + 0.0003*sinDeg(3*anomaly)
+	eclipticLon := math.Mod(anomaly+center+180+102.9372, 360)
+
+	transit := julianJ2000 + meanSolar + 0.0053*sinDeg(anomaly) - 0.0069*sinDeg(2*eclipticLon)
+
+	// Declination of the sun and the hour angle at which it crosses the horizon
+	sinDecl := sinDeg(eclipticLon) * sinDeg(23.4397)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	cosHourAngle := (sinDeg(-0.833) - sinDeg(lat)*sinDecl) / (cosDeg(lat) * cosDecl)
+	if cosHourAngle > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("the sun does not rise on %s at this latitude", day.Format("2006-01-02"))
+	}
+	if cosHourAngle < -1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("the sun does not set on %s at this latitude", day.Format("2006-01-02"))
+	}
+	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+
+	sunrise = fromJulian(transit - hourAngle/360).In(date.Location())
+	sunset = fromJulian(transit + hourAngle/360).In(date.Location())
+	return sunrise, sunset, nil
+}
+
+// sunTarget returns the next end time that is offset before sunset or after
+// sunrise (exactly one of the offsets is set), using the configured coordinates
+func sunTarget(beforeSunset, afterSunrise string, now time.Time) (time.Time, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if cfg.Latitude == nil || cfg.Longitude == nil {
+		return time.Time{}, fmt.Errorf("sunrise/sunset timers need \"latitude\" and \"longitude\" in %s", getConfigPath())
+	}
+	lat, lon := *cfg.Latitude, *cfg.Longitude
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return time.Time{}, fmt.Errorf("invalid coordinates %.4f, %.4f in %s", lat, lon, getConfigPath())
+	}
+
+	offsetStr := beforeSunset
+	if afterSunrise != "" {
+		offsetStr = afterSunrise
+	}
+	offset, err := parseDuration(offsetStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid offset: %w", err)
+	}
+
+	// Use today's event, or tomorrow's if today's has already passed
+	for day := 0; day < 2; day++ {
+		sunrise, sunset, err := sunTimes(now.AddDate(0, 0, day), lat, lon)
+		if err != nil {
+			return time.Time{}, err
+		}
+		end := sunset.Add(-offset)
+		if afterSunrise != "" {
+			end = sunrise.Add(offset)
+		}
+		if end.After(now) {
+			return end, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no upcoming sunrise/sunset found")
+}
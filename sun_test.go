@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestSunTimes(t *testing.T) {
+	tests := []struct {
+		zone            string
+		lat, lon        float64
+		date            string
+		sunrise, sunset string
+	}{
+		{"America/New_York", 40.7128, -74.0060, "2026-12-21", "07:17", "16:32"},
+		{"America/Los_Angeles", 34.0522, -118.2437, "2026-06-10", "05:41", "20:05"},
+		{"Europe/London", 51.5074, -0.1278, "2026-03-20", "06:03", "18:14"},
+		{"Australia/Sydney", -33.8688, 151.2093, "2026-06-21", "07:00", "16:54"},
+	}
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		date, _ := time.ParseInLocation("2006-01-02", tt.date, loc)
+		sunrise, sunset, err := sunTimes(date, tt.lat, tt.lon)
+		if err != nil {
+			t.Errorf("%s: %v", tt.zone, err)
+			continue
+		}
+		for _, c := range []struct {
+			name string
+			got  time.Time
+			want string
+		}{{"sunrise", sunrise, tt.sunrise}, {"sunset", sunset, tt.sunset}} {
+			want, _ := time.ParseInLocation("2006-01-02 15:04", tt.date+" "+c.want, loc)
+			if diff := c.got.Sub(want).Abs(); diff > 3*time.Minute {
+				t.Errorf("%s %s %s = %v, want about %v", tt.zone, tt.date, c.name, c.got, want)
+			}
+		}
+	}
+}
+
+func TestSunTimesPolarNight(t *testing.T) {
+	date := time.Date(2026, time.December, 21, 0, 0, 0, 0, time.UTC)
+	if _, _, err := sunTimes(date, 78.2232, 15.6267); err == nil {
+		t.Error("sunTimes in Svalbard in December succeeded, want an error")
+	}
+}
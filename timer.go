@@ -1,18 +1,113 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// nowFunc returns the current time. Everything outside the tick loop reads the
+// clock through it so tests can pin the time. The monotonic reading is
+// dropped: it stops while the machine sleeps, so end times compared with it
+// would lag the wall clock by however long the laptop was shut.
+var nowFunc = func() time.Time { return time.Now().Round(0) }
+
 type Timer struct {
+	ID        string        `json:"id,omitempty"` // stable identity; assigned on load when missing
 	Name      string        `json:"name"`
 	End       time.Time     `json:"end"`
 	Paused    bool          `json:"paused"`
 	Remaining time.Duration `json:"remaining"`
 	Duration  time.Duration `json:"duration"`
+
+	// Created is when the timer was added; zero (oldest) when unknown
+	Created time.Time `json:"created,omitzero"`
+
+	// Started is when the current countdown began; zero until it first runs
+	Started time.Time `json:"started,omitzero"`
+
+	// Repeat restarts the countdown every interval once it completes (0 = no repeat)
+	Repeat time.Duration `json:"repeat,omitempty"`
+
+	// Weekly schedule: when set, a completed timer rolls to the next matching day
+	Weekdays  weekdayMask `json:"weekdays,omitempty"`
+	TimeOfDay string      `json:"timeOfDay,omitempty"` // "HH:MM"
+
+	Tags  []string `json:"tags,omitempty"`
+	Color string   `json:"color,omitempty"` // label color for the first tag (name, number or #hex)
+	Note  string   `json:"note,omitempty"`  // free-form context such as a URL or description
+
+	// Pinned timers are listed above the rest whatever the sort
+	Pinned bool `json:"pinned,omitempty"`
+
+	// Next is the ID of a paused timer to start when this one completes (pomodoro chains)
+	Next string `json:"next,omitempty"`
+
+	QuietPaused bool `json:"quietPaused,omitempty"` // paused automatically for quiet hours
+	BulkPaused  bool `json:"bulkPaused,omitempty"`  // paused by the last Pause-All
+	Notified    bool `json:"notified,omitempty"`    // completion notification already sent
+
+	// Speed scales how fast the timer counts down (2 = twice real time).
+	// Zero means real time so timers saved before this field keep working.
+	Speed float64 `json:"speed,omitempty"`
+
+	// Billing: hourly rate charged for time the timer has been running
+	Billable bool    `json:"billable,omitempty"`
+	Rate     float64 `json:"rate,omitempty"`
+}
+
+// newTimerID returns a random identifier for a new timer
+func newTimerID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// Fall back to the clock; uniqueness within one file is all that matters
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// assignMissingIDs gives an ID to timers saved before IDs existed
+func assignMissingIDs(timers []Timer) {
+	for i := range timers {
+		if timers[i].ID == "" {
+			timers[i].ID = newTimerID()
+		}
+	}
+}
+
+// matchesSearch reports whether the timer matches a lowercase search query:
+// "#tag" matches tags by prefix, anything else is a substring of the name
+func (t Timer) matchesSearch(query string) bool {
+	if tag, ok := strings.CutPrefix(query, "#"); ok {
+		for _, have := range t.Tags {
+			if strings.HasPrefix(strings.ToLower(have), tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(strings.ToLower(t.Name), query)
+}
+
+// mergeTags combines tag lists in order, dropping blanks and duplicates
+func mergeTags(lists ...[]string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, tag := range list {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return result
 }
 
 func parseDuration(input string) (time.Duration, error) {
@@ -22,6 +117,140 @@ func parseDuration(input string) (time.Duration, error) {
 		return 0, fmt.Errorf("empty input")
 	}
 
+	// Inputs with operators are evaluated as expressions (e.g., "3*(25m+5m)")
+	if strings.ContainsAny(input, exprOperators) {
+		return evalDurationExpr(input)
+	}
+
+	return parseSimpleDuration(input)
+}
+
+// untilLayouts are the absolute date-time formats accepted by parseUntil
+var untilLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseUntil parses an absolute target: a clock time like "17:00" (today, or
+// tomorrow if already past) or a local date-time like "2025-06-01T09:00"
+func parseUntil(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, fmt.Errorf("empty input")
+	}
+
+	if hour, minute, err := parseTimeOfDay(input); err == nil {
+		end := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !end.After(now) {
+			end = end.AddDate(0, 0, 1)
+		}
+		return end, nil
+	}
+
+	for _, layout := range untilLayouts {
+		end, err := time.ParseInLocation(layout, input, now.Location())
+		if err != nil {
+			continue
+		}
+		if !end.After(now) {
+			return time.Time{}, fmt.Errorf("%s is in the past", end.Format("2006-01-02 15:04"))
+		}
+		return end, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use HH:MM or YYYY-MM-DDTHH:MM)", input)
+}
+
+// parseNaturalTime parses a phrase like "tomorrow 9am", "next monday 14:00",
+// "friday noon" or "5:30pm": an optional day (today, tomorrow, a weekday name,
+// or "next" and a weekday) followed by a clock time. Without a day the time is
+// today, or tomorrow if already past; a bare weekday is its next occurrence,
+// which may be later today. "next" always skips today.
+func parseNaturalTime(input string, now time.Time) (time.Time, error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("empty input")
+	}
+
+	day := now
+	// Once the time has passed: roll a day (no day given), a week (bare weekday) or fail
+	roll := 1
+	switch {
+	case fields[0] == "today":
+		fields, roll = fields[1:], 0
+	case fields[0] == "tomorrow":
+		day, fields, roll = now.AddDate(0, 0, 1), fields[1:], 0
+	default:
+		next := fields[0] == "next"
+		if next {
+			fields = fields[1:]
+		}
+		if len(fields) > 0 {
+			if wd, ok := parseWeekdayName(fields[0]); ok {
+				days := (int(wd) - int(now.Weekday()) + 7) % 7
+				roll = 7
+				if next {
+					roll = 0
+					if days == 0 {
+						days = 7
+					}
+				}
+				day, fields = now.AddDate(0, 0, days), fields[1:]
+			} else if next {
+				return time.Time{}, fmt.Errorf("expected a weekday after \"next\" in %q", input)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("missing a time in %q (e.g. \"tomorrow 9am\")", input)
+	}
+	hour, minute, ok := parseClock(strings.Join(fields, ""))
+	if !ok {
+		return time.Time{}, fmt.Errorf("cannot understand %q (use an optional day like today, tomorrow or friday, then a time like 9am or 14:00)", input)
+	}
+
+	end := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+	if !end.After(now) {
+		if roll == 0 {
+			return time.Time{}, fmt.Errorf("%s is in the past", end.Format("2006-01-02 15:04"))
+		}
+		end = end.AddDate(0, 0, roll)
+	}
+	return end, nil
+}
+
+// parseWeekdayName recognizes full and three-letter English weekday names
+func parseWeekdayName(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock parses "14:00", "9am", "9:30pm", "noon" or "midnight"
+func parseClock(s string) (hour, minute int, ok bool) {
+	switch s {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+	for _, layout := range []string{"15:04", "3pm", "3:04pm"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Hour(), t.Minute(), true
+		}
+	}
+	return 0, 0, false
+}
+
+// parseSimpleDuration parses a trimmed, lowercase sequence of number-suffix pairs
+func parseSimpleDuration(input string) (time.Duration, error) {
 	var total time.Duration
 
 	// Parse multiple number-suffix pairs (e.g., "30d30m", "1h30m", "2d")
@@ -33,17 +262,23 @@ func parseDuration(input string) (time.Duration, error) {
 			continue
 		}
 
-		// Parse number
+		// Parse number, allowing a single decimal point (e.g., "1.5h")
 		numStart := i
-		for i < len(input) && input[i] >= '0' && input[i] <= '9' {
+		for i < len(input) && (input[i] >= '0' && input[i] <= '9' || input[i] == '.') {
 			i++
 		}
 		if i == numStart {
 			return 0, fmt.Errorf("expected number at position %d", numStart)
 		}
 		numStr := input[numStart:i]
+		if strings.Count(numStr, ".") > 1 {
+			return 0, fmt.Errorf("invalid number %s: more than one decimal point", numStr)
+		}
+		if strings.HasSuffix(numStr, ".") {
+			return 0, fmt.Errorf("invalid number %s: expected digits after the decimal point", numStr)
+		}
 
-		num, err := strconv.Atoi(numStr)
+		num, err := strconv.ParseFloat(numStr, 64)
 		if err != nil {
 			return 0, fmt.Errorf("invalid number %s: %w", numStr, err)
 		}
@@ -57,27 +292,43 @@ func parseDuration(input string) (time.Duration, error) {
 		if i < len(input) {
 			suffix = input[i : i+1]
 			i++
+			// "mo" is months; a lone "m" stays minutes
+			if suffix == "m" && i < len(input) && input[i] == 'o' {
+				suffix = "mo"
+				i++
+			}
 		} else {
 			// No suffix means seconds (e.g., "30" = 30s)
 			suffix = "s"
 		}
 
-		var d time.Duration
+		var unit time.Duration
 		switch suffix {
 		case "s":
-			d = time.Duration(num) * time.Second
+			unit = time.Second
 		case "m":
-			d = time.Duration(num) * time.Minute
+			unit = time.Minute
 		case "h":
-			d = time.Duration(num) * time.Hour
+			unit = time.Hour
 		case "d":
-			d = time.Duration(num) * 24 * time.Hour
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		case "mo":
+			unit = 30 * 24 * time.Hour // matches formatDuration
 		case "y":
-			d = time.Duration(num) * 365 * 24 * time.Hour
+			unit = 365 * 24 * time.Hour
 		default:
-			return 0, fmt.Errorf("invalid suffix: %s (use s, m, h, d, y)", suffix)
+			return 0, fmt.Errorf("invalid suffix: %s (use s, m, h, d, w, mo, y)", suffix)
+		}
+		d, err := scaleDuration(unit, num)
+		if err != nil {
+			return 0, err
+		}
+		total, err = addDuration(total, d)
+		if err != nil {
+			return 0, err
 		}
-		total += d
 	}
 
 	if total <= 0 {
@@ -87,8 +338,41 @@ func parseDuration(input string) (time.Duration, error) {
 	return total, nil
 }
 
+// mulDuration multiplies d by n, failing instead of silently overflowing
+func mulDuration(d time.Duration, n int64) (time.Duration, error) {
+	if d < 0 || n < 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	if n != 0 && int64(d) > math.MaxInt64/n {
+		return 0, fmt.Errorf("duration too large")
+	}
+	return d * time.Duration(n), nil
+}
+
+// scaleDuration multiplies d by a possibly fractional factor, using exact
+// integer math for whole numbers
+func scaleDuration(d time.Duration, f float64) (time.Duration, error) {
+	if f == math.Trunc(f) && f < math.MaxInt64 {
+		return mulDuration(d, int64(f))
+	}
+	scaled := math.Round(float64(d) * f)
+	if scaled >= math.MaxInt64 {
+		return 0, fmt.Errorf("duration too large")
+	}
+	return time.Duration(scaled), nil
+}
+
+// addDuration adds two non-negative durations, failing instead of silently overflowing
+func addDuration(a, b time.Duration) (time.Duration, error) {
+	if a > math.MaxInt64-b {
+		return 0, fmt.Errorf("duration too large")
+	}
+	return a + b, nil
+}
+
 func formatDuration(d time.Duration) string {
-	d = d.Round(time.Second)
+	// Durations shown are never negative; a clock that jumped back shows 0s
+	d = max(d, 0).Round(time.Second)
 	totalSeconds := int(d.Seconds())
 
 	days := totalSeconds / 86400
@@ -123,15 +407,12 @@ func formatDuration(d time.Duration) string {
 		return strings.Join(parts, " ")
 	}
 
-	// For shorter durations, show more detail
-	if years > 0 {
-		parts = append(parts, fmt.Sprintf("%dy", years))
-	}
-	if months > 0 {
-		parts = append(parts, fmt.Sprintf("%dmo", months))
+	// For shorter durations, show more detail, counting whole weeks
+	if days >= 7 {
+		parts = append(parts, fmt.Sprintf("%dw", days/7))
 	}
-	if remainingDays > 0 {
-		parts = append(parts, fmt.Sprintf("%dd", remainingDays))
+	if days%7 > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days%7))
 	}
 	if hours > 0 {
 		parts = append(parts, fmt.Sprintf("%dh", hours))
@@ -149,48 +430,287 @@ func formatDuration(d time.Duration) string {
 	return strings.Join(parts, " ")
 }
 
+// speed returns the countdown rate, treating unset as real time
+func (t Timer) speed() float64 {
+	if t.Speed <= 0 {
+		return 1
+	}
+	return t.Speed
+}
+
+// toRealTime converts timer time (Duration, Remaining) into wall-clock time
+func (t Timer) toRealTime(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / t.speed())
+}
+
+// remainingAt returns the timer time left at now, negative once done.
+// Paused timers report their frozen Remaining.
+func (t Timer) remainingAt(now time.Time) time.Duration {
+	if t.Paused {
+		return t.Remaining
+	}
+	if t.speed() == 1 {
+		return t.End.Sub(now)
+	}
+	// Keep far-off (or zero) end times from overflowing once scaled
+	limit := float64(100 * 365 * 24 * time.Hour)
+	return time.Duration(max(-limit, min(float64(t.End.Sub(now))*t.speed(), limit)))
+}
+
+// elapsedAt returns how much of the duration has counted down by now, in
+// wall-clock time. Paused time is not included since Remaining is frozen.
+func (t Timer) elapsedAt(now time.Time) time.Duration {
+	elapsed := t.Duration - t.remainingAt(now)
+	elapsed = max(0, min(elapsed, t.Duration))
+	return t.toRealTime(elapsed)
+}
+
+// costAt returns the accrued cost of a billable timer at now
+func (t Timer) costAt(now time.Time) float64 {
+	if !t.Billable {
+		return 0
+	}
+	return t.elapsedAt(now).Hours() * t.Rate
+}
+
+// pause freezes a running timer. It reports whether the timer was paused.
+func (t *Timer) pause(now time.Time) bool {
+	if t.Paused || !t.End.After(now) {
+		return false
+	}
+	t.Remaining = t.remainingAt(now)
+	t.Paused = true
+	return true
+}
+
+// resume restarts the clock on a paused timer. It reports whether the timer was resumed.
+func (t *Timer) resume(now time.Time) bool {
+	if !t.Paused || t.Remaining <= 0 {
+		return false
+	}
+	t.End = now.Add(t.toRealTime(t.Remaining))
+	if t.Started.IsZero() {
+		t.Started = now
+	}
+	t.Paused = false
+	t.Remaining = 0
+	t.QuietPaused = false
+	t.BulkPaused = false
+	return true
+}
+
+// snooze gives the timer d more time and sets it running. A done timer gets d
+// from now rather than from its old end.
+func (t *Timer) snooze(now time.Time, d time.Duration) {
+	t.resume(now)
+	base := t.End
+	if t.Paused || !base.After(now) {
+		// A done timer starts a new countdown
+		base = now
+		t.Started = now
+	}
+	t.End = base.Add(t.toRealTime(d))
+	t.Paused = false
+	t.Remaining = 0
+	t.QuietPaused = false
+	t.BulkPaused = false
+	t.Notified = false
+}
+
+// duplicate returns a running copy of the timer, started from its full duration
+func (t Timer) duplicate(now time.Time) Timer {
+	c := t
+	c.ID = newTimerID()
+	c.Created = now
+	c.Name = t.Name + " (copy)"
+	c.Tags = append([]string(nil), t.Tags...)
+	c.BulkPaused = false
+	c.Next = "" // one chain link is enough
+	c.restart(now, false)
+	return c
+}
+
+// restart resets the timer to its full duration. With keepPaused, a paused timer
+// stays paused holding its full duration instead of starting to run.
+func (t *Timer) restart(now time.Time, keepPaused bool) {
+	t.End = now.Add(t.toRealTime(t.Duration))
+	t.QuietPaused = false
+	t.BulkPaused = false
+	t.Notified = false
+	if keepPaused && t.Paused {
+		// Counts as started once resumed
+		t.Started = time.Time{}
+		t.Remaining = t.Duration
+		return
+	}
+	t.Started = now
+	t.Paused = false
+	t.Remaining = 0
+}
+
+// reset puts the timer back to its full duration, paused, unlike restart
+// which sets it running. It starts when resumed.
+func (t *Timer) reset(now time.Time) {
+	t.Paused = true
+	t.restart(now, true)
+}
+
+// setDuration changes the duration without restarting: the end (or a paused
+// timer's Remaining) moves by the difference, so elapsed progress is kept. A
+// paused timer keeps at least 1 second, like adjustRemaining.
+func (t *Timer) setDuration(now time.Time, d time.Duration) {
+	delta := d - t.Duration
+	t.Duration = d
+	if t.Paused {
+		t.Remaining = max(t.Remaining+delta, time.Second)
+		return
+	}
+	t.End = t.End.Add(t.toRealTime(delta))
+	if t.End.After(now) {
+		t.Notified = false
+	}
+}
+
+// soonestActive returns the running timer that ends next
+func soonestActive(timers []Timer, now time.Time) (Timer, bool) {
+	var best Timer
+	found := false
+	for _, t := range timers {
+		if t.Paused || !t.End.After(now) {
+			continue
+		}
+		if !found || t.End.Before(best.End) {
+			best = t
+			found = true
+		}
+	}
+	return best, found
+}
+
+// totalRemaining sums the time left on timers: running ones count down to
+// their end, paused ones count their frozen Remaining and done ones nothing
+func totalRemaining(timers []Timer, now time.Time) time.Duration {
+	var total time.Duration
+	for _, t := range timers {
+		total += max(t.remainingAt(now), 0)
+	}
+	return total
+}
+
 func (t Timer) StatusEmoji(now time.Time) string {
 	if t.Paused {
 		return "⏸️"
 	}
-	remaining := time.Until(t.End)
+	remaining := t.remainingAt(now)
 	if remaining <= 0 {
 		return "✅"
 	}
 	return "⏳️"
 }
 
+// status returns "paused", "done" or "active"
+func (t Timer) status(now time.Time) string {
+	switch {
+	case t.Paused:
+		return "paused"
+	case t.remainingAt(now) <= 0:
+		return "done"
+	default:
+		return "active"
+	}
+}
+
+// fineDisplayThreshold is the remaining time below which StatusText shows tenths of a second
+const fineDisplayThreshold = 10 * time.Second
+
 func (t Timer) StatusText(now time.Time) string {
 	if t.Paused {
-		return formatDuration(t.Remaining)
+		return formatRemaining(t.Remaining)
 	}
-	remaining := time.Until(t.End)
+	remaining := t.remainingAt(now)
 	if remaining <= 0 {
 		return "Done"
 	}
-	return formatDuration(remaining)
+	return formatRemaining(remaining)
 }
 
-func (t Timer) EndTimeText(now time.Time) string {
+// formatRemaining formats a countdown, with tenths of a second near the end
+func formatRemaining(d time.Duration) string {
+	if d > 0 && d < fineDisplayThreshold {
+		return fmt.Sprintf("%.1fs", d.Truncate(100*time.Millisecond).Seconds())
+	}
+	return formatDuration(d)
+}
+
+// maxAgoShown caps how long ago a finished timer is said to have ended; a
+// wrong clock or a zero end time would otherwise show centuries
+const maxAgoShown = 365 * 24 * time.Hour
+
+// formatAgo formats how long ago a timer ended, e.g. "5m ago"
+func formatAgo(d time.Duration) string {
+	if d > maxAgoShown {
+		return fmt.Sprintf("over %s ago", formatDuration(maxAgoShown))
+	}
+	return fmt.Sprintf("%s ago", formatDuration(d))
+}
+
+// doneFor returns how long ago a finished timer ended
+func (t Timer) doneFor(now time.Time) time.Duration {
+	return max(now.Sub(t.End), 0)
+}
+
+// sinceStarted returns how long ago the current countdown began, up to its
+// end once done; false when the start is unknown
+func (t Timer) sinceStarted(now time.Time) (time.Duration, bool) {
+	if t.Started.IsZero() {
+		return 0, false
+	}
+	if t.status(now) == "done" {
+		now = t.End
+	}
+	return max(now.Sub(t.Started), 0), true
+}
+
+// ElapsedText formats the time since the countdown started, or "-" when unknown
+func (t Timer) ElapsedText(now time.Time) string {
+	elapsed, ok := t.sinceStarted(now)
+	if !ok {
+		return "-"
+	}
+	return formatDuration(elapsed)
+}
+
+// EndTimeText formats the end time in loc, or how long ago a done timer finished
+func (t Timer) EndTimeText(now time.Time, loc *time.Location, layouts endTimeLayouts) string {
 	if t.Paused {
 		return "(paused)"
 	}
-	remaining := time.Until(t.End)
-	if remaining <= 0 {
-		elapsed := t.Duration - remaining
-		return fmt.Sprintf("+%s", formatDuration(elapsed))
+	if t.remainingAt(now) <= 0 {
+		return formatAgo(t.doneFor(now))
 	}
-	return formatEndTime(t.End, now)
+	return formatEndTime(t.End, now, loc, layouts)
+}
+
+// endTimeLayouts are the time layouts for an end time today, this month, this
+// year and in another year
+type endTimeLayouts struct {
+	sameDay, sameMonth, sameYear, full string
 }
 
-func formatEndTime(end, now time.Time) string {
+// tuiEndTimeLayouts are the TUI's compact end time layouts; the sameDayFormat,
+// sameMonthFormat, sameYearFormat and fullDateFormat config replace them
+var tuiEndTimeLayouts = endTimeLayouts{"15:04:05", "2 15:04", "2/01 15:04", "2/01/06 15:04"}
+
+// formatEndTime formats end in loc, dropping the parts it shares with now
+func formatEndTime(end, now time.Time, loc *time.Location, layouts endTimeLayouts) string {
+	end, now = end.In(loc), now.In(loc)
 	if end.Day() == now.Day() && end.Month() == now.Month() && end.Year() == now.Year() {
-		return end.Format("15:04:05")
+		return end.Format(layouts.sameDay)
 	} else if end.Month() == now.Month() && end.Year() == now.Year() {
-		return end.Format("2 15:04")
+		return end.Format(layouts.sameMonth)
 	} else if end.Year() == now.Year() {
-		return end.Format("2/01 15:04")
+		return end.Format(layouts.sameYear)
 	} else {
-		return end.Format("2/01/06 15:04")
+		return end.Format(layouts.full)
 	}
 }
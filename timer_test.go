@@ -0,0 +1,140 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetDuration(t *testing.T) {
+	running := Timer{Duration: 30 * time.Minute, Started: testNow.Add(-20 * time.Minute), End: testNow.Add(10 * time.Minute)}
+	running.setDuration(testNow, time.Hour)
+	if want := testNow.Add(40 * time.Minute); !running.End.Equal(want) {
+		t.Errorf("running End = %v, want %v", running.End, want)
+	}
+
+	paused := Timer{Duration: 30 * time.Minute, Paused: true, Remaining: 10 * time.Minute}
+	paused.setDuration(testNow, 25*time.Minute)
+	if paused.Remaining != 5*time.Minute {
+		t.Errorf("paused Remaining = %v, want 5m", paused.Remaining)
+	}
+}
+
+func TestSetDurationKeepsPausedTimerAlive(t *testing.T) {
+	paused := Timer{Duration: 30 * time.Minute, Paused: true, Remaining: 10 * time.Minute}
+	paused.setDuration(testNow, 20*time.Minute)
+	if paused.Remaining != time.Second {
+		t.Errorf("Remaining = %v, want it clamped to 1s", paused.Remaining)
+	}
+	if paused.Duration != 20*time.Minute {
+		t.Errorf("Duration = %v, want 20m", paused.Duration)
+	}
+}
+
+func TestParseDurationMonths(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"2mo", 60 * day},
+		{"1y2mo3d", 365*day + 60*day + 3*day},
+		{"1mo30m", 30*day + 30*time.Minute}, // m after mo is still minutes
+		{"0.5mo", 15 * day},
+		{"2MO", 60 * day},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.input)
+		if err != nil {
+			t.Errorf("parseDuration(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	for _, input := range []string{"mo", "2mox", "2o"} {
+		if d, err := parseDuration(input); err == nil {
+			t.Errorf("parseDuration(%q) = %v, want error", input, d)
+		}
+	}
+}
+
+func TestMonthsRoundTrip(t *testing.T) {
+	// What formatDuration shows for long timers can be typed back in
+	const day = 24 * time.Hour
+	for _, d := range []time.Duration{60 * day, 90 * day, 95 * day, 425 * day} {
+		text := formatDuration(d)
+		got, err := parseDuration(text)
+		if err != nil || got != d {
+			t.Errorf("parseDuration(formatDuration(%v) = %q) = %v, %v", d, text, got, err)
+		}
+	}
+}
+
+func TestDetectLargestUnitMonths(t *testing.T) {
+	for input, want := range map[string]string{"2mo": "mo", "2mo5m": "mo", "5m": "m", "1y2mo": "y"} {
+		if got := detectLargestUnit(input); got != want {
+			t.Errorf("detectLargestUnit(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestParseNaturalTime(t *testing.T) {
+	// testNow is Tuesday 2026-03-10 12:00 UTC
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"5:30pm", at(10, 17, 30)},
+		{"9am", at(11, 9, 0)}, // already past, so tomorrow
+		{"noon", at(11, 12, 0)},
+		{"today 18:00", at(10, 18, 0)},
+		{"tomorrow 9am", at(11, 9, 0)},
+		{"Tomorrow  midnight", at(11, 0, 0)},
+		{"friday noon", at(13, 12, 0)},
+		{"fri 8:15am", at(13, 8, 15)},
+		{"tuesday 14:00", at(10, 14, 0)}, // later today
+		{"tuesday 9am", at(17, 9, 0)},    // past today, so next week
+		{"next tuesday 14:00", at(17, 14, 0)},
+		{"next monday 9am", at(16, 9, 0)},
+	}
+	for _, tt := range tests {
+		got, err := parseNaturalTime(tt.input, testNow)
+		if err != nil {
+			t.Errorf("parseNaturalTime(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseNaturalTime(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseNaturalTimeInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"tomorrow",
+		"next",
+		"next 9am",
+		"today 9am", // in the past
+		"friday 25:00",
+		"someday 9am",
+	} {
+		if got, err := parseNaturalTime(input, testNow); err == nil {
+			t.Errorf("parseNaturalTime(%q) = %v, want error", input, got)
+		}
+	}
+}
+
+func TestDoneAgoText(t *testing.T) {
+	done := Timer{Duration: 5 * time.Minute, Started: testNow.Add(-390 * time.Second), End: testNow.Add(-90 * time.Second)}
+	if got := done.EndTimeText(testNow, time.UTC, tuiEndTimeLayouts); got != "1m 30s ago" {
+		t.Errorf("EndTimeText = %q, want \"1m 30s ago\"", got)
+	}
+	if got := done.doneFor(testNow); got != 90*time.Second {
+		t.Errorf("doneFor = %v, want 1m30s, not the duration plus the overshoot", got)
+	}
+}
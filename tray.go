@@ -0,0 +1,75 @@
+//go:build tray
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/systray"
+)
+
+// runTray shows the soonest-ending timer in the system tray until quit
+func runTray() error {
+	systray.Run(onTrayReady, nil)
+	return nil
+}
+
+func onTrayReady() {
+	systray.SetTitle("go-countdown")
+	systray.SetTooltip("go-countdown")
+
+	pauseAll := systray.AddMenuItem("Pause all", "Pause all active timers")
+	resumeAll := systray.AddMenuItem("Resume all", "Resume all paused timers")
+	add5 := systray.AddMenuItem("Add 5m timer", "Add a 5 minute timer")
+	add25 := systray.AddMenuItem("Add 25m timer", "Add a 25 minute timer")
+	systray.AddSeparator()
+	quit := systray.AddMenuItem("Quit", "Close the tray icon")
+
+	updateTray()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			// Menu actions share the CLI command logic so the file stays consistent
+			select {
+			case <-ticker.C:
+			case <-pauseAll.ClickedCh:
+				_ = executeCLICommand("pause", []string{"--all"})
+			case <-resumeAll.ClickedCh:
+				_ = executeCLICommand("resume", []string{"--all"})
+			case <-add5.ClickedCh:
+				_ = executeCLICommand("add", []string{"Timer", "5m"})
+			case <-add25.ClickedCh:
+				_ = executeCLICommand("add", []string{"Timer", "25m"})
+			case <-quit.ClickedCh:
+				systray.Quit()
+				return
+			}
+			updateTray()
+		}
+	}()
+}
+
+// updateTray reloads the timers and shows the soonest one in the title and tooltip
+func updateTray() {
+	timers, err := loadTimers()
+	if err != nil {
+		systray.SetTitle("⏳")
+		systray.SetTooltip("go-countdown: no timers")
+		return
+	}
+
+	now := nowFunc()
+	t, ok := soonestActive(timers, now)
+	if !ok {
+		systray.SetTitle("⏳")
+		systray.SetTooltip("go-countdown: no active timers")
+		return
+	}
+
+	text := fmt.Sprintf("%s %s", t.Name, t.StatusText(now))
+	systray.SetTitle(text)
+	systray.SetTooltip(text)
+}
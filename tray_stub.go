@@ -0,0 +1,10 @@
+//go:build !tray
+
+package main
+
+import "fmt"
+
+// runTray reports that the tray integration was not compiled in
+func runTray() error {
+	return fmt.Errorf("tray support not built in; rebuild with: go build -tags tray")
+}
@@ -3,12 +3,14 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
 )
 
 type (
@@ -24,32 +26,82 @@ const (
 	stateEditing
 	stateConfirmDelete
 	stateConfirmRestart
+	stateConfirmReset // resetting to the full duration, paused
 	stateConfirmBulk
+	stateConfirmLong // confirming a timer longer than the configured threshold
+	stateInfo        // read-only details of the selected timer
 )
 
+// maxUndo bounds how many quick deletions can be undone
+const maxUndo = 10
+
+// deletedTimer remembers a quick-deleted timer and where it was in the list
+type deletedTimer struct {
+	timer Timer
+	index int
+}
+
 type model struct {
 	// Timer data
 	timers []Timer
 	now    time.Time
 	cursor int
+	offset int // first visible timer in the table, kept while the cursor stays in view
 	table  table.Model
 	filter filterMode
 
+	// Display order, applied after filtering
+	sortMode    timerSort
+	sortReverse bool
+
 	// UI state
-	state uiState
+	state     uiState
+	compact   bool // compact rows: status, name and remaining only
+	focusMode bool // the selected timer fills the screen in large digits
+	sequence  bool // timeline of the selected timer's tag group under the table
+
+	// Name search, layered on top of the status filter
+	searching   bool   // search box has focus
+	searchQuery string // case-insensitive substring matched against names
+	searchInput textinput.Model
+
+	// Move-to-position prompt: the selected timer goes to the typed 1-based row
+	moving    bool
+	moveInput textinput.Model
+
+	// Transient message shown in place of the summary line, e.g. after copying
+	flashMsg   string
+	flashUntil time.Time
+
+	// Mouse drag reordering
+	dragging bool // a row is being dragged with the left button
 
 	// Form/operation state
 	editingIndex      int            // actual index of timer being edited
+	undoStack         []deletedTimer // quick-deleted timers, most recent last
 	pendingBulkAction bulkActionType // which bulk action to execute
+	confirmOpened     time.Time      // when the open delete, restart or bulk confirmation appeared
+	formState         uiState        // form (adding/editing) awaiting long duration confirmation
+	pendingDuration   time.Duration  // duration awaiting long duration confirmation
 	nameInput         textinput.Model
 	durationInput     textinput.Model
+	untilMode         bool            // duration input takes an end time instead
+	startPaused       bool            // new timer is created paused
+	keepProgress      bool            // editing shifts the end by the duration change instead of restarting
+	formError         string          // why the last enter didn't submit the form
+	repeatInput       textinput.Model // optional repeat interval
+	tagsInput         textinput.Model // comma-separated tags
+	noteInput         textinput.Model // optional free-form note
 
 	// Duration adjustment config
 	durationConfig DurationAdjustConfig
+	quiet          bool // inside quiet hours as of the last tick
 
 	// Persistence
+	loadErr     error // timers file exists but is corrupt or too new; saving is disabled until reset
 	dirty       bool
-	lastModTime time.Time // track file modification time for external changes
+	lastModTime time.Time         // track file modification time for external changes
+	watcher     *fsnotify.Watcher // nil when falling back to polling
 
 	// Key bindings and help
 	defaultKeys defaultKeyMap
@@ -62,12 +114,35 @@ type model struct {
 	height int
 }
 
+// confirming reports whether a confirmation popup is open
+func (m model) confirming() bool {
+	switch m.state {
+	case stateConfirmDelete, stateConfirmRestart, stateConfirmReset, stateConfirmBulk, stateConfirmLong:
+		return true
+	}
+	return false
+}
+
 func (m model) Init() tea.Cmd {
-	return tea.Batch(tick(), fileWatchTick())
+	if m.watcher != nil {
+		return tea.Batch(tick(m.tickInterval()), waitForSaveFileChange(m.watcher))
+	}
+	return tea.Batch(tick(m.tickInterval()), fileWatchTick())
 }
 
-func tick() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+// tickInterval ticks fast enough to animate tenths of a second while a running
+// timer is under fineDisplayThreshold, and once a second otherwise
+func (m model) tickInterval() time.Duration {
+	for _, t := range m.timers {
+		if remaining := t.remainingAt(m.now); !t.Paused && remaining > 0 && remaining < fineDisplayThreshold {
+			return 100 * time.Millisecond
+		}
+	}
+	return time.Second
+}
+
+func tick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -78,18 +153,51 @@ func fileWatchTick() tea.Cmd {
 	})
 }
 
-func initialModel() model {
-	// Define table columns
-	columns := []table.Column{
-		{Title: "Stat", Width: 6},
-		{Title: "Name", Width: 22},
-		{Title: "Remaining", Width: 17},
-		{Title: "End Time", Width: 17},
+// validateDurationInput limits duration inputs to characters parseDuration accepts
+func validateDurationInput(s string) error {
+	// Allow empty string during typing
+	if s == "" {
+		return nil
 	}
+	// Validate: only digits, decimal points, s/m/h/d/w/mo/y suffixes and expression operators allowed
+	for i, r := range s {
+		if r == 'o' && i > 0 && s[i-1] == 'm' {
+			continue
+		}
+		if strings.ContainsRune(exprOperators, r) {
+			continue
+		}
+		if (r < '0' || r > '9') && r != '.' && r != 's' && r != 'm' && r != 'h' && r != 'd' && r != 'w' && r != 'y' && r != ' ' {
+			return fmt.Errorf("invalid duration format")
+		}
+	}
+	return nil
+}
+
+// validatePositionInput limits the move prompt to a row number
+func validatePositionInput(s string) error {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("invalid position")
+		}
+	}
+	return nil
+}
 
+// validateUntilInput limits the end time input to clock times and dates
+func validateUntilInput(s string) error {
+	for _, r := range s {
+		if (r < '0' || r > '9') && !strings.ContainsRune(":-T ", r) {
+			return fmt.Errorf("invalid time format")
+		}
+	}
+	return nil
+}
+
+func initialModel() model {
 	// Create table with styles
 	tbl := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(tableColumns(false, 0, nil)),
 		table.WithFocused(true),
 		table.WithHeight(10), // Will be dynamic based on viewport
 	)
@@ -101,20 +209,29 @@ func initialModel() model {
 	nameInput.Focus()
 
 	durationInput := textinput.New()
-	durationInput.Placeholder = "30s, 5m, 1h, 2d, 1y"
-	durationInput.Validate = func(s string) error {
-		// Allow empty string during typing
-		if s == "" {
-			return nil
-		}
-		// Validate: only digits and s/m/h/d/y suffixes allowed
-		for _, r := range s {
-			if (r < '0' || r > '9') && r != 's' && r != 'm' && r != 'h' && r != 'd' && r != 'y' && r != ' ' {
-				return fmt.Errorf("invalid duration format")
-			}
-		}
-		return nil
-	}
+	durationInput.Placeholder = "30s, 5m, 1h, 2d, 3w, 1y"
+	durationInput.Validate = validateDurationInput
+
+	repeatInput := textinput.New()
+	repeatInput.Placeholder = "optional, e.g. 1d"
+	repeatInput.Validate = validateDurationInput
+
+	tagsInput := textinput.New()
+	tagsInput.Placeholder = "optional, e.g. work, client-a"
+
+	noteInput := textinput.New()
+	noteInput.Placeholder = "optional, e.g. a link or description"
+	noteInput.Width = 40 // scroll long URLs instead of wrapping the form
+
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+	searchInput.Placeholder = "search"
+	searchInput.Width = 14
+
+	moveInput := textinput.New()
+	moveInput.Prompt = "move to #"
+	moveInput.Width = 5
+	moveInput.Validate = validatePositionInput
 
 	// Load duration adjustment config
 	cfg, err := loadConfig()
@@ -123,27 +240,43 @@ func initialModel() model {
 	}
 
 	m := model{
-		now:            time.Now(),
+		now:            nowFunc(),
 		filter:         filterAll,
 		state:          stateDefault,
-		defaultKeys:    newDefaultKeyMap(),
-		formKeys:       newFormKeyMap(),
+		defaultKeys:    newDefaultKeyMap(cfg.Keybindings),
+		formKeys:       newFormKeyMap(cfg.Keybindings),
 		confirmKeys:    newConfirmKeyMap(),
 		help:           help.New(),
 		table:          tbl,
 		nameInput:      nameInput,
 		durationInput:  durationInput,
+		repeatInput:    repeatInput,
+		tagsInput:      tagsInput,
+		noteInput:      noteInput,
+		searchInput:    searchInput,
+		moveInput:      moveInput,
 		durationConfig: cfg,
+		compact:        loadUIState().Compact,
 	}
+	refreshTableColumns(&m) // apply the configured columns and density
 
 	if s, err := loadFromFile(); err == nil {
 		applySaveData(&m, s)
+	} else if unreadableSave(err) {
+		m.loadErr = err
 	}
+	// Only timers finishing while the TUI runs trigger notifications
+	m.notifyCompleted(true)
+
+	// Start opposite to the current quiet state so the first tick applies it,
+	// including resuming timers left quiet-paused by an earlier session
+	m.quiet = !cfg.inQuietHours(m.now)
 
 	// Get initial file modification time
 	if info, err := os.Stat(saveFile); err == nil {
 		m.lastModTime = info.ModTime()
 	}
+	m.watcher = newSaveFileWatcher()
 
 	return m
 }
@@ -1,10 +1,29 @@
 package main
 
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
 func (m model) getVisibleTimers() []Timer {
 	var result []Timer
+	query := strings.ToLower(m.searchQuery)
 	for _, t := range m.timers {
+		if query != "" && !t.matchesSearch(query) {
+			continue
+		}
 		switch m.filter {
 		case filterAll:
+			// Optionally treat done timers as an archive only shown under the done filter
+			if m.durationConfig.AllExcludesDone && !t.Paused && !t.End.After(m.now) {
+				continue
+			}
 			result = append(result, t)
 		case filterActive:
 			if !t.Paused && t.End.After(m.now) {
@@ -20,9 +39,31 @@ func (m model) getVisibleTimers() []Timer {
 			}
 		}
 	}
+	sortTimers(result, m.sortMode, m.sortReverse, m.now)
+	if m.durationConfig.DoneLast {
+		partitionDone(result, m.now)
+	}
+	partitionPinned(result)
 	return result
 }
 
+// resizeTable fits the table above the summary and help, leaving room for
+// the sequence panel when it is shown, and keeps the cursor in view
+func (m *model) resizeTable() {
+	height := m.height - 6 // Leave room for summary and help
+	if m.sequence {
+		height -= sequencePanelHeight
+	}
+	m.table.SetHeight(height)
+	m.setCursor(m.cursor)
+}
+
+// canReorder reports whether the visible order is the saved order, which is
+// the only order manual reordering makes sense in
+func (m model) canReorder() bool {
+	return m.sortMode == sortManual && !m.sortReverse
+}
+
 func (m model) getActualTimerIndex(visibleIndex int) int {
 	visibleTimers := m.getVisibleTimers()
 	if visibleIndex < 0 || visibleIndex >= len(visibleTimers) {
@@ -30,9 +71,616 @@ func (m model) getActualTimerIndex(visibleIndex int) int {
 	}
 	targetTimer := visibleTimers[visibleIndex]
 	for i, t := range m.timers {
-		if t.Name == targetTimer.Name && t.End.Equal(targetTimer.End) {
+		if t.ID == targetTimer.ID {
 			return i
 		}
 	}
 	return -1
 }
+
+// confirmAction carries out the delete, restart or bulk action awaiting
+// confirmation and closes the popup
+func (m *model) confirmAction() {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	switch m.state {
+	case stateConfirmDelete:
+		if actualIdx >= 0 {
+			m.timers = append(m.timers[:actualIdx], m.timers[actualIdx+1:]...)
+			visibleTimers := m.getVisibleTimers()
+			if m.cursor >= len(visibleTimers) && m.cursor > 0 {
+				m.cursor--
+			}
+			m.dirty = true
+		}
+	case stateConfirmRestart:
+		if actualIdx >= 0 && m.timers[actualIdx].Duration > 0 {
+			m.timers[actualIdx].restart(m.now, false)
+			m.dirty = true
+		}
+	case stateConfirmReset:
+		if actualIdx >= 0 && m.timers[actualIdx].Duration > 0 {
+			m.timers[actualIdx].reset(m.now)
+			m.selectTimer(m.timers[actualIdx])
+			m.dirty = true
+		}
+	case stateConfirmBulk:
+		switch m.pendingBulkAction {
+		case bulkPauseAll:
+			count := 0
+			for i := range m.timers {
+				if m.timers[i].pause(m.now) {
+					m.timers[i].BulkPaused = true
+					count++
+				}
+			}
+			if count > 0 {
+				m.dirty = true
+			}
+		case bulkResumeAll:
+			// Timers paused by hand stay paused
+			count := 0
+			for i := range m.timers {
+				if m.timers[i].BulkPaused && m.timers[i].resume(m.now) {
+					count++
+				}
+			}
+			if count > 0 {
+				m.dirty = true
+			}
+		case bulkPauseVisible:
+			count := 0
+			for _, idx := range m.visibleTimerIndices() {
+				if m.timers[idx].pause(m.now) {
+					m.timers[idx].BulkPaused = true
+					count++
+				}
+			}
+			if count > 0 {
+				m.dirty = true
+			}
+		case bulkResumeVisible:
+			count := 0
+			for _, idx := range m.visibleTimerIndices() {
+				if m.timers[idx].BulkPaused && m.timers[idx].resume(m.now) {
+					count++
+				}
+			}
+			if count > 0 {
+				m.dirty = true
+			}
+		case bulkDeleteDone:
+			newTimers := make([]Timer, 0, len(m.timers))
+			for _, t := range m.timers {
+				if t.Paused || t.End.After(m.now) {
+					newTimers = append(newTimers, t)
+				} else {
+					m.dirty = true
+				}
+			}
+			m.timers = newTimers
+			// Adjust cursor if needed
+			visibleTimers := m.getVisibleTimers()
+			if m.cursor >= len(visibleTimers) && m.cursor > 0 {
+				m.cursor = len(visibleTimers) - 1
+			}
+		case bulkRestartAll:
+			count := 0
+			for i := range m.timers {
+				if m.timers[i].Duration > 0 {
+					m.timers[i].restart(m.now, false)
+					count++
+				}
+			}
+			if count > 0 {
+				m.dirty = true
+			}
+		}
+	}
+	m.state = stateDefault
+}
+
+// openConfirm shows a delete, restart or bulk confirmation, noting when it
+// opened so confirmTimeout can cancel it
+func (m *model) openConfirm(state uiState) {
+	m.state = state
+	m.confirmOpened = m.now
+}
+
+// expireConfirm cancels a confirmation left open past confirmTimeout, so a
+// stray key much later can't confirm a stale action
+func (m *model) expireConfirm() {
+	timeout := m.durationConfig.confirmTimeout()
+	if timeout <= 0 {
+		return
+	}
+	switch m.state {
+	case stateConfirmDelete, stateConfirmRestart, stateConfirmReset, stateConfirmBulk:
+		if m.now.Sub(m.confirmOpened) >= timeout {
+			m.state = stateDefault
+		}
+	}
+}
+
+// visibleTimerIndices returns the actual indices of the visible timers,
+// resolved up front so changing a timer's status can't shift the rest
+func (m model) visibleTimerIndices() []int {
+	var indices []int
+	for i := range m.getVisibleTimers() {
+		indices = append(indices, m.getActualTimerIndex(i))
+	}
+	return indices
+}
+
+// clampCursor keeps the cursor inside the visible timers after the set shrinks
+func (m *model) clampCursor() {
+	visibleTimers := m.getVisibleTimers()
+	m.setCursor(min(m.cursor, max(0, len(visibleTimers)-1)))
+}
+
+// setSearch applies a new name search query
+func (m *model) setSearch(query string) {
+	m.searchQuery = query
+	m.clampCursor()
+}
+
+// selectTimer moves the cursor to the given timer and reports whether it is visible
+func (m *model) selectTimer(target Timer) bool {
+	for i, t := range m.getVisibleTimers() {
+		if t.ID == target.ID {
+			m.setCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
+// setCursor moves the cursor to visible index i, scrolling the table only as
+// far as needed to keep it in view
+func (m *model) setCursor(i int) {
+	m.cursor = i
+	m.offset = m.tableOffset()
+}
+
+// tableOffset returns the first visible timer shown in the table: the saved
+// offset, moved just enough to show the cursor and fill the viewport
+func (m model) tableOffset() int {
+	height := m.table.Height()
+	offset := min(m.offset, m.cursor)
+	offset = max(offset, m.cursor-height+1)
+	offset = min(offset, len(m.getVisibleTimers())-height)
+	return max(offset, 0)
+}
+
+// setFilter switches the status filter, staying on the selected timer when
+// the new filter still shows it
+func (m *model) setFilter(f filterMode) {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	m.filter = f
+	if actualIdx >= 0 && m.selectTimer(m.timers[actualIdx]) {
+		return
+	}
+	m.clampCursor()
+}
+
+// formInputs returns the add/edit form inputs in focus order
+func (m *model) formInputs() []*textinput.Model {
+	return []*textinput.Model{&m.nameInput, &m.durationInput, &m.repeatInput, &m.tagsInput, &m.noteInput}
+}
+
+// focusFormInput moves focus delta inputs forward (or back), wrapping around
+func (m *model) focusFormInput(delta int) {
+	inputs := m.formInputs()
+	current := 0
+	for i, in := range inputs {
+		if in.Focused() {
+			current = i
+		}
+		in.Blur()
+	}
+	inputs[(current+delta+len(inputs))%len(inputs)].Focus()
+}
+
+// clearFocusedInput empties whichever form input has focus
+func (m *model) clearFocusedInput() {
+	for _, in := range m.formInputs() {
+		if in.Focused() {
+			in.Reset()
+		}
+	}
+}
+
+// resetForm clears the form inputs and focuses the name
+func (m *model) resetForm() {
+	for _, in := range m.formInputs() {
+		in.Reset()
+		in.Blur()
+	}
+	m.setUntilMode(false)
+	m.startPaused = false
+	m.keepProgress = false
+	m.formError = ""
+	m.nameInput.Focus()
+}
+
+// rejectForm keeps the form open with msg shown and the offending field focused
+func (m *model) rejectForm(msg string, field *textinput.Model) {
+	m.formError = msg
+	for _, in := range m.formInputs() {
+		in.Blur()
+	}
+	field.Focus()
+}
+
+// setUntilMode switches the duration input between a duration and an end time
+func (m *model) setUntilMode(on bool) {
+	m.untilMode = on
+	m.durationInput.SetValue("")
+	if on {
+		m.durationInput.Placeholder = "17:00, 2025-06-01T09:00"
+		m.durationInput.Validate = validateUntilInput
+	} else {
+		m.durationInput.Placeholder = "30s, 5m, 1h, 2d, 3w, 1y"
+		m.durationInput.Validate = validateDurationInput
+	}
+}
+
+// formDuration parses the duration input, converting an end time in until mode
+func (m model) formDuration() (time.Duration, error) {
+	if !m.untilMode {
+		return parseDuration(m.durationInput.Value())
+	}
+	now := nowFunc()
+	end, err := parseUntil(m.durationInput.Value(), now)
+	if err != nil {
+		return 0, err
+	}
+	return end.Sub(now), nil
+}
+
+// adjustingDuration reports whether the form's +/- keys step the duration
+// rather than type into it: the duration input has focus and holds a plain
+// duration, not an expression
+func (m model) adjustingDuration() bool {
+	return m.durationInput.Focused() && !m.untilMode && !strings.ContainsAny(m.durationInput.Value(), exprOperators)
+}
+
+// formTags parses the comma-separated tags input
+func (m model) formTags() []string {
+	return mergeTags(strings.Split(m.tagsInput.Value(), ","))
+}
+
+// formRepeat parses the optional repeat input, where empty means no repeat
+func (m model) formRepeat() (time.Duration, error) {
+	if strings.TrimSpace(m.repeatInput.Value()) == "" {
+		return 0, nil
+	}
+	return parseDuration(m.repeatInput.Value())
+}
+
+// submitForm saves the add/edit form as a new or updated timer and closes the form
+func (m *model) submitForm(editing bool, name string, duration time.Duration) {
+	repeat, _ := m.formRepeat()
+	if editing {
+		// Update existing timer
+		t := &m.timers[m.editingIndex]
+		t.Name = name
+		t.Repeat = repeat
+		t.Tags = m.formTags()
+		t.Note = strings.TrimSpace(m.noteInput.Value())
+		// An end time typed in until mode always restarts the countdown
+		if m.keepProgress && !m.untilMode {
+			t.setDuration(nowFunc(), duration)
+		} else {
+			t.Duration = duration
+			t.restart(nowFunc(), false)
+		}
+	} else {
+		// Add new timer
+		newTimer := Timer{
+			ID:       newTimerID(),
+			Name:     name,
+			End:      nowFunc().Add(duration),
+			Duration: duration,
+			Created:  nowFunc(),
+			Started:  nowFunc(),
+			Repeat:   repeat,
+			Tags:     mergeTags(m.durationConfig.newTimerTags(), m.formTags()),
+			Note:     strings.TrimSpace(m.noteInput.Value()),
+		}
+		if m.startPaused {
+			// Resuming starts the clock from the full duration
+			newTimer.Paused = true
+			newTimer.Remaining = duration
+			newTimer.Started = time.Time{}
+		}
+		m.timers = append(m.timers, newTimer)
+		visibleTimers := m.getVisibleTimers()
+		m.setCursor(len(visibleTimers) - 1)
+	}
+	m.dirty = true
+
+	// Reset and close form
+	m.state = stateDefault
+	m.resetForm()
+}
+
+// moveTimer moves the timer at visible index from to visible index to,
+// shifting the timers in between, and keeps the cursor on the moved timer
+func (m *model) moveTimer(from, to int) {
+	if !m.canReorder() {
+		return
+	}
+	// Pinned timers stay above the rest, so a timer only moves within its group
+	visible := m.getVisibleTimers()
+	if from < 0 || from >= len(visible) {
+		return
+	}
+	pinned := 0
+	for _, t := range visible {
+		if t.Pinned {
+			pinned++
+		}
+	}
+	if visible[from].Pinned {
+		to = min(to, pinned-1)
+	} else {
+		to = max(to, pinned)
+	}
+	src := m.getActualTimerIndex(from)
+	dst := m.getActualTimerIndex(to)
+	if src < 0 || dst < 0 || src == dst {
+		return
+	}
+
+	t := m.timers[src]
+	m.timers = append(m.timers[:src], m.timers[src+1:]...)
+	m.timers = append(m.timers[:dst], append([]Timer{t}, m.timers[dst:]...)...)
+
+	m.setCursor(to)
+	m.dirty = true
+}
+
+// togglePin pins or unpins the selected timer, keeping the cursor on it
+func (m *model) togglePin() {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		return
+	}
+	t := &m.timers[actualIdx]
+	t.Pinned = !t.Pinned
+	m.selectTimer(*t)
+	m.dirty = true
+}
+
+// duplicateSelected inserts a running copy of the selected timer right after it
+func (m *model) duplicateSelected() {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		return
+	}
+	c := m.timers[actualIdx].duplicate(m.now)
+	m.timers = append(m.timers[:actualIdx+1], append([]Timer{c}, m.timers[actualIdx+1:]...)...)
+	m.selectTimer(c)
+	m.dirty = true
+}
+
+// save writes the timers now, remembering the file's new modification time
+// so the watcher doesn't reload our own write
+func (m *model) save() {
+	if err := saveToFile(*m); err != nil {
+		return
+	}
+	m.dirty = false
+	if info, err := os.Stat(saveFile); err == nil {
+		m.lastModTime = info.ModTime()
+	}
+}
+
+// saveOnExit saves the model the program finished with if it still has
+// unsaved changes. Quitting with q saves first; this covers SIGTERM and
+// SIGINT, which bubbletea turns into a quit without asking the model.
+func saveOnExit(final tea.Model) {
+	if m, ok := final.(model); ok && m.dirty {
+		m.save()
+	}
+}
+
+// adjustSelectedRemaining moves the selected timer's end by one step
+// (direction 1 or -1) without restarting it
+func (m *model) adjustSelectedRemaining(direction int) {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		return
+	}
+	t := &m.timers[actualIdx]
+	step := remainingStep(max(t.remainingAt(m.now), 0), m.durationConfig)
+	if t.adjustRemaining(m.now, time.Duration(direction)*step) {
+		m.dirty = true
+	}
+}
+
+// showInfo opens the details popup for the selected timer
+func (m *model) showInfo() {
+	if m.getActualTimerIndex(m.cursor) >= 0 {
+		m.state = stateInfo
+	}
+}
+
+// flashDuration is how long a flash message replaces the summary line
+const flashDuration = time.Second
+
+// flash shows a short message in place of the summary line
+func (m *model) flash(msg string) {
+	m.flashMsg = msg
+	m.flashUntil = m.now.Add(flashDuration)
+}
+
+// copyRemaining puts the selected timer's remaining time on the clipboard,
+// for pasting into chat
+func (m *model) copyRemaining() {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		return
+	}
+	text := m.timers[actualIdx].StatusText(m.now)
+	if err := clipboard.WriteAll(text); err != nil {
+		m.flash("clipboard unavailable")
+		return
+	}
+	m.flash(fmt.Sprintf("copied %q", text))
+}
+
+// quickDelete removes the selected timer without confirmation, remembering it
+// so undoDelete can put it back
+func (m *model) quickDelete() {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		return
+	}
+
+	m.undoStack = append(m.undoStack, deletedTimer{timer: m.timers[actualIdx], index: actualIdx})
+	if len(m.undoStack) > maxUndo {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndo:]
+	}
+	m.timers = append(m.timers[:actualIdx], m.timers[actualIdx+1:]...)
+	m.clampCursor()
+	m.dirty = true
+}
+
+// undoDelete restores the most recently quick-deleted timer at its old index
+func (m *model) undoDelete() {
+	if len(m.undoStack) == 0 {
+		return
+	}
+	d := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	idx := min(d.index, len(m.timers))
+	m.timers = append(m.timers[:idx], append([]Timer{d.timer}, m.timers[idx:]...)...)
+	m.selectTimer(d.timer)
+	m.dirty = true
+}
+
+// rowAtY returns the visible timer index shown on screen line y, or -1
+func (m model) rowAtY(y int) int {
+	// Line 0 holds the filter title and the table header
+	if y < 1 || y > m.table.Height() {
+		return -1
+	}
+	row := m.tableOffset() + y - 1
+	if row >= len(m.getVisibleTimers()) {
+		return -1
+	}
+	return row
+}
+
+// notifyCompleted marks timers that have just finished and returns commands
+// sending their desktop notifications and sound. With silent set, timers are
+// only marked, which keeps timers that finished before the TUI started quiet.
+func (m *model) notifyCompleted(silent bool) []tea.Cmd {
+	var cmds []tea.Cmd
+	completed := false
+	for i := range m.timers {
+		t := &m.timers[i]
+		if t.Notified || t.Paused || t.remainingAt(m.now) > 0 {
+			continue
+		}
+		t.Notified = true
+		m.dirty = true
+		completed = true
+		if !silent && !m.durationConfig.DisableNotifications {
+			cmds = append(cmds, notifyCmd("Timer done", fmt.Sprintf("%q has finished", t.Name)))
+		}
+	}
+	// One sound per tick, even when several timers finish together
+	if completed && !silent && m.durationConfig.Sound {
+		cmds = append(cmds, soundCmd(m.durationConfig.SoundFile))
+	}
+	return cmds
+}
+
+// reloadTimers replaces the timers with ones reloaded from disk
+func (m *model) reloadTimers(s saveData) {
+	keepNotified(m.timers, s.Timers)
+	m.replaceTimers(func() { applySaveData(m, s) })
+}
+
+// replaceTimers runs change, which swaps or removes timers, keeping the cursor
+// on the selected timer and any open form or confirmation attached to the
+// timer it was opened for
+func (m *model) replaceTimers(change func()) {
+	selectedID := ""
+	if visible := m.getVisibleTimers(); m.cursor >= 0 && m.cursor < len(visible) {
+		selectedID = visible[m.cursor].ID
+	}
+	editing := m.state == stateEditing || (m.state == stateConfirmLong && m.formState == stateEditing)
+	editingID := ""
+	if editing && m.editingIndex < len(m.timers) {
+		editingID = m.timers[m.editingIndex].ID
+	}
+
+	change()
+
+	if !m.selectTimer(Timer{ID: selectedID}) {
+		m.clampCursor()
+		// A delete or restart confirmation must not move on to another timer
+		if m.state == stateConfirmDelete || m.state == stateConfirmRestart || m.state == stateConfirmReset {
+			m.state = stateDefault
+		}
+	}
+
+	if editing {
+		m.editingIndex = -1
+		for i, t := range m.timers {
+			if t.ID == editingID {
+				m.editingIndex = i
+			}
+		}
+		// The timer was removed elsewhere; keep the input as a new timer
+		if m.editingIndex < 0 {
+			if m.state == stateEditing {
+				m.state = stateAdding
+			} else {
+				m.formState = stateAdding
+			}
+		}
+	}
+}
+
+// keepNotified carries the notified flag over to reloaded timers that still end
+// at the same time, so completions are not announced twice
+func keepNotified(old, reloaded []Timer) {
+	notified := make(map[string]time.Time)
+	for _, t := range old {
+		if t.Notified {
+			notified[t.ID] = t.End
+		}
+	}
+	for i := range reloaded {
+		if end, ok := notified[reloaded[i].ID]; ok && end.Equal(reloaded[i].End) {
+			reloaded[i].Notified = true
+		}
+	}
+}
+
+// applyQuietHours pauses running timers when quiet hours begin and resumes
+// the ones it paused when they end. Manually paused timers are left alone.
+func (m *model) applyQuietHours() {
+	quiet := m.durationConfig.inQuietHours(m.now)
+	if quiet == m.quiet {
+		return
+	}
+	m.quiet = quiet
+
+	for i := range m.timers {
+		t := &m.timers[i]
+		if quiet && t.pause(m.now) {
+			t.QuietPaused = true
+			m.dirty = true
+		} else if !quiet && t.QuietPaused {
+			t.resume(m.now)
+			t.QuietPaused = false
+			m.dirty = true
+		}
+	}
+}
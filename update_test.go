@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mixedTimers returns an active, a paused and a done timer at testNow
+func mixedTimers() []Timer {
+	return []Timer{
+		{ID: "a", Name: "Active", End: testNow.Add(time.Hour), Duration: 2 * time.Hour},
+		{ID: "p", Name: "Paused", Paused: true, Remaining: 30 * time.Minute, Duration: time.Hour},
+		{ID: "d", Name: "Done", End: testNow.Add(-time.Minute), Duration: time.Hour},
+	}
+}
+
+func TestAllFilterShowsDoneByDefault(t *testing.T) {
+	useTempFiles(t)
+	m := newTestModel(t, mixedTimers())
+
+	if got := timerNames(m.getVisibleTimers()); !slices.Equal(got, []string{"Active", "Paused", "Done"}) {
+		t.Errorf("All shows %v, want all three timers", got)
+	}
+	if panel := renderFilterPanel(m); strings.Contains(panel, "All (live)") {
+		t.Errorf("filter panel labels All as live without allExcludesDone:\n%s", panel)
+	}
+}
+
+func TestAllExcludesDone(t *testing.T) {
+	useTempFiles(t)
+	if err := os.WriteFile(configFile, []byte(`{"allExcludesDone": true}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel(t, mixedTimers())
+
+	if got := timerNames(m.getVisibleTimers()); !slices.Equal(got, []string{"Active", "Paused"}) {
+		t.Errorf("All shows %v, want only the live timers", got)
+	}
+	if panel := renderFilterPanel(m); !strings.Contains(panel, "All (live)") {
+		t.Errorf("filter panel doesn't label All as live:\n%s", panel)
+	}
+
+	// Done timers are still under the done filter
+	m.setFilter(filterDone)
+	if got := timerNames(m.getVisibleTimers()); !slices.Equal(got, []string{"Done"}) {
+		t.Errorf("Done shows %v, want [Done]", got)
+	}
+}
+
+func TestTickAfterEightHourJump(t *testing.T) {
+	useTempFiles(t)
+	m := newTestModel(t, []Timer{
+		{ID: "a", Name: "Running", Duration: time.Hour, Started: testNow, End: testNow.Add(time.Hour)},
+		{ID: "b", Name: "Paused", Duration: time.Hour, Paused: true, Remaining: 10 * time.Minute},
+	})
+
+	// The laptop slept for 8 hours
+	later := testNow.Add(8 * time.Hour)
+	next, _ := m.Update(tickMsg(later))
+	m = next.(model)
+
+	running, paused := m.timers[0], m.timers[1]
+	if running.status(m.now) != "done" || !running.Notified {
+		t.Errorf("running timer is %s (notified %v), want done and notified", running.status(m.now), running.Notified)
+	}
+	if got := running.EndTimeText(m.now, time.UTC, tuiEndTimeLayouts); got != "7h ago" {
+		t.Errorf("EndTimeText = %q, want \"7h ago\"", got)
+	}
+	if paused.Remaining != 10*time.Minute {
+		t.Errorf("paused Remaining = %v after the jump, want 10m", paused.Remaining)
+	}
+
+	// Resuming counts the remaining time from the new now
+	setClock(later)
+	paused.resume(nowFunc())
+	if want := later.Add(10 * time.Minute); !paused.End.Equal(want) {
+		t.Errorf("resumed End = %v, want %v", paused.End, want)
+	}
+}
+
+func TestClockJumpingBackShowsNoNegatives(t *testing.T) {
+	timer := Timer{Duration: time.Hour, Started: testNow, End: testNow.Add(-time.Minute)}
+	earlier := testNow.Add(-8 * time.Hour)
+
+	if got := timer.doneFor(earlier); got != 0 {
+		t.Errorf("doneFor = %v, want 0", got)
+	}
+	if got, _ := timer.sinceStarted(earlier); got != 0 {
+		t.Errorf("sinceStarted = %v, want 0", got)
+	}
+	if got := formatDuration(-8 * time.Hour); got != "0s" {
+		t.Errorf("formatDuration(-8h) = %q, want \"0s\"", got)
+	}
+}
+
+func TestDoneAgoIsCapped(t *testing.T) {
+	ancient := Timer{Duration: time.Minute, End: time.Time{}}
+	if got := ancient.EndTimeText(testNow, time.UTC, tuiEndTimeLayouts); got != "over 1y ago" {
+		t.Errorf("EndTimeText for a zero end = %q, want \"over 1y ago\"", got)
+	}
+}
+
+func TestSaveOnExitOnlyWhenDirty(t *testing.T) {
+	useTempFiles(t)
+	m := newTestModel(t, mixedTimers())
+	m.save() // marking the done timer notified leaves it dirty
+	before, err := os.ReadFile(saveFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing unsaved: the file is left as it was
+	m.timers[0].Name = "Changed"
+	saveOnExit(m)
+	if after, _ := os.ReadFile(saveFile); string(after) != string(before) {
+		t.Error("saveOnExit wrote a clean model")
+	}
+
+	m.dirty = true
+	saveOnExit(m)
+	timers, err := loadTimers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timers[0].Name != "Changed" {
+		t.Errorf("saveOnExit didn't write the dirty model: first timer is %q", timers[0].Name)
+	}
+}
+
+func TestSaveOnExitKeepsUnreadableFile(t *testing.T) {
+	useTempFiles(t)
+	if err := os.WriteFile(saveFile, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel()
+	m.timers = mixedTimers()
+	m.dirty = true
+	saveOnExit(m)
+	if b, _ := os.ReadFile(saveFile); string(b) != "{not json" {
+		t.Errorf("saveOnExit overwrote the corrupt file:\n%s", b)
+	}
+}
+
+func TestAutoDeleteClosesConfirmation(t *testing.T) {
+	useTempFiles(t)
+	writeConfig(t, `{"autoDeleteDoneAfter": "1h"}`)
+	m := newTestModel(t, []Timer{
+		{ID: "a", Name: "Done", Duration: time.Minute, Started: testNow.Add(-2 * time.Minute), End: testNow.Add(-time.Minute)},
+	})
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = next.(model)
+	if m.state != stateConfirmDelete {
+		t.Fatalf("state = %v after d, want the delete confirmation", m.state)
+	}
+
+	// The timer is auto-deleted while the confirmation is open
+	next, _ = m.Update(tickMsg(testNow.Add(2 * time.Hour)))
+	m = next.(model)
+	if len(m.timers) != 0 {
+		t.Fatalf("timers = %v, want the done timer auto-deleted", timerNames(m.timers))
+	}
+	if m.confirming() {
+		t.Errorf("state = %v, want the confirmation for the deleted timer closed", m.state)
+	}
+
+	// Answering the stale confirmation must not touch a missing timer
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+}
+
+func TestResizeKeepsCursorInView(t *testing.T) {
+	useTempFiles(t)
+	var timers []Timer
+	for i := range 20 {
+		timers = append(timers, Timer{ID: fmt.Sprint(i), Name: fmt.Sprint("Timer ", i), Duration: time.Hour, End: testNow.Add(time.Hour)})
+	}
+	m := newTestModel(t, timers)
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = next.(model)
+	m.setCursor(19)
+
+	// Shrinking the terminal must scroll the selected row back into view
+	next, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 16})
+	m = next.(model)
+	if height := m.table.Height(); m.cursor < m.offset || m.cursor >= m.offset+height {
+		t.Errorf("cursor %d outside rows %d-%d after shrinking", m.cursor, m.offset, m.offset+height-1)
+	}
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func renderFilterPanel(m model) string {
@@ -20,6 +25,10 @@ func renderFilterPanel(m model) string {
 		{"4", "Done", filterDone},
 	}
 
+	if m.durationConfig.AllExcludesDone {
+		filters[0].label = "All (live)"
+	}
+
 	var b strings.Builder
 	b.WriteString(" Filters\n")
 	for _, f := range filters {
@@ -29,77 +38,444 @@ func renderFilterPanel(m model) string {
 		}
 		fmt.Fprintf(&b, "%s %s %s\n", prefix, f.num, f.label)
 	}
+
+	if m.sortMode != sortManual || m.sortReverse {
+		arrow := "↑"
+		if m.sortReverse {
+			arrow = "↓"
+		}
+		fmt.Fprintf(&b, "\n Sort: %s %s\n", m.sortMode, arrow)
+	}
+
+	if m.moving {
+		b.WriteString("\n " + m.moveInput.View() + "\n")
+	}
+	if m.searching {
+		b.WriteString("\n " + m.searchInput.View() + "\n")
+	} else if m.searchQuery != "" {
+		fmt.Fprintf(&b, "\n /%s (esc)\n", m.searchQuery)
+	}
 	return b.String()
 }
 
+// tableColumnSpecs are the table columns by their config name, in default order
+var tableColumnSpecs = []struct {
+	name   string
+	column table.Column
+}{
+	{"status", table.Column{Title: "Stat", Width: 6}},
+	{"name", table.Column{Title: "Name", Width: 22}},
+	{"tag", table.Column{Title: "Tag", Width: 10}},
+	{"remaining", table.Column{Title: "Remaining", Width: 17}},
+	{"progress", table.Column{Title: "Progress", Width: 12}},
+	{"end", table.Column{Title: "End Time", Width: 17}},
+	{"note", table.Column{Title: "Note", Width: 20}},
+	{"elapsed", table.Column{Title: "Elapsed", Width: 12}},
+}
+
+// optionalColumns are only shown when listed in the columns config
+var optionalColumns = []string{"elapsed"}
+
+// tableColumn returns the column with the given config name
+func tableColumn(name string) (table.Column, bool) {
+	for _, spec := range tableColumnSpecs {
+		if spec.name == name {
+			return spec.column, true
+		}
+	}
+	return table.Column{}, false
+}
+
+// columnIndex returns the index of the column with the given title, or -1
+func columnIndex(columns []table.Column, title string) int {
+	return slices.IndexFunc(columns, func(c table.Column) bool { return c.Title == title })
+}
+
+// tableColumns returns the table columns for the given density and terminal
+// width, limited to names (all columns when empty). Compact mode drops the tag,
+// progress, end time and note. The progress column only appears when the terminal is
+// wide enough, and the name column absorbs any spare width.
+func tableColumns(compact bool, width int, names []string) []table.Column {
+	if len(names) == 0 {
+		for _, spec := range tableColumnSpecs {
+			if !slices.Contains(optionalColumns, spec.name) {
+				names = append(names, spec.name)
+			}
+		}
+	}
+
+	var columns []table.Column
+	progressAt := -1
+	for _, name := range names {
+		if compact && (name == "tag" || name == "progress" || name == "end" || name == "note") {
+			continue
+		}
+		if name == "progress" {
+			progressAt = len(columns) // added below if it fits
+			continue
+		}
+		if c, ok := tableColumn(name); ok {
+			columns = append(columns, c)
+		}
+	}
+
+	nameIdx := columnIndex(columns, "Name")
+	if width > 0 && nameIdx >= 0 {
+		// Filter panel and padding take 25 chars, each cell has 2 chars of padding
+		spare := width - 25 - 2*len(columns)
+		for i, c := range columns {
+			if i != nameIdx {
+				spare -= c.Width
+			}
+		}
+		progress, _ := tableColumn("progress")
+		if progressAt >= 0 && spare-columns[nameIdx].Width >= progress.Width+2 {
+			columns = slices.Insert(columns, progressAt, progress)
+			spare -= progress.Width + 2
+			if progressAt <= nameIdx {
+				nameIdx++
+			}
+		}
+		if spare > columns[nameIdx].Width {
+			columns[nameIdx].Width = spare
+		}
+	}
+	return columns
+}
+
+// refreshTableColumns applies the columns for the current density and width
+func refreshTableColumns(m *model) {
+	// Clear rows first so they never outnumber the new columns
+	m.table.SetRows(nil)
+	m.table.SetColumns(tableColumns(m.compact, m.width, m.durationConfig.Columns))
+}
+
 // updateTableRows populates the table with timer data
 func updateTableRows(m *model) {
 	visibleTimers := m.getVisibleTimers()
+	nameWidth := m.table.Columns()[columnIndex(m.table.Columns(), "Name")].Width - 2
+	if noColor {
+		nameWidth -= 2 // room for the selection marker
+	}
+
+	// Only the rows in view are handed to the table, which would otherwise
+	// scroll on its own to follow the cursor
+	offset := m.tableOffset()
+	end := min(offset+m.table.Height(), len(visibleTimers))
 
 	var rows []table.Row
-	for _, t := range visibleTimers {
+	for i, t := range visibleTimers[offset:end] {
 		status := t.StatusEmoji(m.now)
 		remainingText := t.StatusText(m.now)
-		endTimeText := t.EndTimeText(m.now)
 
-		// Truncate name if too long
-		name := t.Name
-		if len(name) > 20 {
-			name = name[:19] + "…"
+		// Truncate name if too long, leaving room for the pin
+		name, width := t.Name, nameWidth
+		if t.Pinned {
+			width -= 3
+		}
+		name = ansi.Truncate(name, width, "…")
+		if t.Pinned {
+			name = "📌 " + name
+		}
+		// Without colors the selected row has no highlight, so mark it
+		if noColor {
+			marker := "  "
+			if offset+i == m.cursor {
+				marker = "> "
+			}
+			name = marker + name
 		}
 
-		row := table.Row{status, name, remainingText, endTimeText}
+		var row table.Row
+		for _, c := range m.table.Columns() {
+			switch c.Title {
+			case "Stat":
+				row = append(row, status)
+			case "Name":
+				row = append(row, name)
+			case "Tag":
+				tag := ""
+				if len(t.Tags) > 0 {
+					tag = t.Tags[0]
+				}
+				row = append(row, tag)
+			case "Remaining":
+				row = append(row, remainingText)
+			case "Progress":
+				row = append(row, progressBar(t, m.now, c.Width-2))
+			case "End Time":
+				row = append(row, t.EndTimeText(m.now, m.durationConfig.displayLocation(), m.durationConfig.endTimeLayouts(tuiEndTimeLayouts)))
+			case "Note":
+				// Keep multi-line notes on the one row
+				row = append(row, strings.Join(strings.Fields(t.Note), " "))
+			case "Elapsed":
+				row = append(row, t.ElapsedText(m.now))
+			}
+		}
 		rows = append(rows, row)
 	}
 
 	m.table.SetRows(rows)
 
 	// Sync cursor position
-	if m.cursor >= 0 && m.cursor < len(rows) {
-		m.table.SetCursor(m.cursor)
+	if m.cursor >= offset && m.cursor < end {
+		m.table.SetCursor(m.cursor - offset)
+	}
+}
+
+// progressBar draws how much of the timer's duration has elapsed. Timers
+// without a duration (imported or legacy) get no bar.
+func progressBar(t Timer, now time.Time, width int) string {
+	if t.Duration <= 0 || width <= 0 {
+		return ""
+	}
+	fraction := 1 - float64(t.remainingAt(now))/float64(t.Duration)
+	fraction = max(0, min(fraction, 1))
+	filled := int(math.Round(fraction * float64(width)))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// tagPalette colors tags that have no explicit color, picked by tag name so a
+// tag always gets the same color
+var tagPalette = []lipgloss.Color{"39", "41", "208", "170", "220", "81", "203", "149"}
+
+// tagColor returns the label color for a timer's first tag
+func tagColor(t Timer) lipgloss.Color {
+	if t.Color != "" {
+		return lipgloss.Color(t.Color)
 	}
+	h := fnv.New32a()
+	h.Write([]byte(t.Tags[0]))
+	return tagPalette[h.Sum32()%uint32(len(tagPalette))]
+}
+
+// overdueAfter is how long a timer can be done before its row turns red
+const overdueAfter = time.Hour
+
+// doneColor returns the row color of a done timer: green while recently
+// done, red once overdue. Timers that aren't done get no color.
+func doneColor(t Timer, now time.Time) (lipgloss.Color, bool) {
+	if t.status(now) != "done" {
+		return "", false
+	}
+	if t.doneFor(now) > overdueAfter {
+		return lipgloss.Color("203"), true
+	}
+	return lipgloss.Color("42"), true
+}
+
+// colorCells colors the tag cell of each rendered row, and the other cells of
+// done rows by how overdue they are. The table truncates cells by
+// byte-counted width, so colors are applied to its output instead. The
+// selected row keeps its highlight untouched.
+func colorCells(view string, m model) string {
+	columns := m.table.Columns()
+	tagIdx := columnIndex(columns, "Tag")
+	visibleTimers := m.getVisibleTimers()
+
+	lines := strings.Split(view, "\n")
+	first := m.tableOffset()
+	for i := 1; i < len(lines); i++ {
+		row := first + i - 1
+		if row >= len(visibleTimers) {
+			break
+		}
+		if row == m.cursor {
+			continue
+		}
+		t := visibleTimers[row]
+		done, isDone := doneColor(t, m.now)
+		var b strings.Builder
+		x := 0
+		for j, c := range columns {
+			width := c.Width + 2 // each cell has one char of padding on both sides
+			cell := ansi.Cut(lines[i], x, x+width)
+			color, ok := done, isDone
+			if j == tagIdx {
+				ok = len(t.Tags) > 0
+				if ok {
+					color = tagColor(t)
+				}
+			}
+			if ok {
+				cell = lipgloss.NewStyle().Foreground(color).Render(cell)
+			}
+			b.WriteString(cell)
+			x += width
+		}
+		b.WriteString(ansi.TruncateLeft(lines[i], x, ""))
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderFooter shows the help line, or a warning while the timers file is corrupt
+func renderFooter(m model) string {
+	if m.loadErr != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(lipgloss.Color("160")).
+			Render(fmt.Sprintf(" %v — not saving. Fix %s, or press X to back it up and start empty, q to quit ", m.loadErr, saveFile))
+	}
+	return m.help.View(m.defaultKeys)
 }
 
 func (m model) View() string {
-	if m.state == stateConfirmDelete || m.state == stateConfirmRestart || m.state == stateConfirmBulk {
+	if m.tooSmall() {
+		return renderSmallView(m)
+	}
+
+	if m.confirming() {
 		return renderPopupOverlay(m)
 	}
 
-	if m.state == stateAdding || m.state == stateEditing {
+	if m.state == stateAdding || m.state == stateEditing || m.state == stateInfo {
 		return renderPopupOverlay(m)
 	}
 
-	// Build filter panel
-	filterPanel := renderFilterPanel(m)
+	if m.focusMode {
+		return renderFocus(m)
+	}
 
-	// Update table rows with current timer data
-	updateTableRows(&m)
+	return renderMainView(m)
+}
 
-	// Build timer table
-	timerTable := m.table.View()
+// Below these sizes the side panel, table and centered popups don't fit
+const (
+	minViewWidth  = 40
+	minViewHeight = 10
+)
 
-	// Combine filter panel and table side by side
-	filterLines := strings.Split(filterPanel, "\n")
-	timerLines := strings.Split(timerTable, "\n")
+// tooSmall reports whether the terminal needs the single-column fallback
+func (m model) tooSmall() bool {
+	return m.width > 0 && (m.width < minViewWidth || m.height < minViewHeight)
+}
 
-	var b strings.Builder
-	maxFilterLines := len(filterLines)
-	for i := 0; i < maxFilterLines || i < len(timerLines); i++ {
-		if i < len(filterLines) {
-			fmt.Fprintf(&b, "%-20s", filterLines[i])
-		} else {
-			b.WriteString(strings.Repeat(" ", 20))
-		}
-		if i < len(timerLines) {
-			b.WriteString(timerLines[i])
+// renderSmallView is the fallback for tiny terminals: the open popup on its
+// own, or a plain timer list, clipped to the terminal instead of overlaid
+func renderSmallView(m model) string {
+	var lines []string
+	switch {
+	case m.confirming():
+		lines = strings.Split(renderConfirmPopup(m), "\n")
+	case m.state == stateInfo:
+		lines = strings.Split(renderInfoPopup(m), "\n")
+	case m.state == stateAdding || m.state == stateEditing:
+		lines = strings.Split(renderPopupForm(m), "\n")
+	default:
+		lines = renderSmallTimerList(m)
+	}
+
+	lines = lines[:min(len(lines), max(m.height, 1))]
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, m.width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderSmallTimerList lists the visible timers one per line, remaining time
+// first, scrolled to keep the cursor in view, above a one-line status
+func renderSmallTimerList(m model) []string {
+	visibleTimers := m.getVisibleTimers()
+	if len(visibleTimers) == 0 {
+		return []string{"No timers (a to add)"}
+	}
+
+	rows := max(m.height-1, 1)
+	start := max(m.cursor-rows+1, 0)
+	var lines []string
+	for i := start; i < min(start+rows, len(visibleTimers)); i++ {
+		t := visibleTimers[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
 		}
-		if i < maxFilterLines-1 || i < len(timerLines)-1 {
-			b.WriteString("\n")
+		lines = append(lines, fmt.Sprintf("%s%-8s %s", marker, t.StatusText(m.now), t.Name))
+	}
+	return append(lines, fmt.Sprintf(" %d/%d · ? help", m.cursor+1, len(visibleTimers)))
+}
+
+// bigGlyphs are 3x5 block characters for the focus mode clock
+var bigGlyphs = map[rune][5]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {"  █", "  █", "  █", "  █", "  █"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	':': {" ", "█", " ", "█", " "},
+}
+
+// bigText renders digits and colons in bigGlyphs, doubling each cell
+// horizontally so the digits aren't squashed by tall terminal cells
+func bigText(s string) []string {
+	var lines [5]strings.Builder
+	for i, r := range s {
+		for row := range lines {
+			if i > 0 {
+				lines[row].WriteString("  ")
+			}
+			for _, c := range bigGlyphs[r][row] {
+				lines[row].WriteString(strings.Repeat(string(c), 2))
+			}
 		}
 	}
+	out := make([]string, len(lines))
+	for i := range lines {
+		out[i] = lines[i].String()
+	}
+	return out
+}
 
-	b.WriteString("\n" + m.help.View(m.defaultKeys))
-	return b.String()
+// formatClock formats a remaining time as M:SS, or H:MM:SS from an hour up
+func formatClock(d time.Duration) string {
+	d = max(d.Round(time.Second), 0)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// renderFocus shows the selected timer's remaining time in large digits,
+// centered, for presenting. It falls back to plain text when they don't fit.
+func renderFocus(m model) string {
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		return renderMainView(m)
+	}
+	t := m.timers[actualIdx]
+
+	remaining := t.remainingAt(m.now)
+	if t.Paused {
+		remaining = t.Remaining
+	}
+	color := lipgloss.Color("42") // green while running
+	switch t.status(m.now) {
+	case "paused":
+		color = lipgloss.Color("220")
+	case "done":
+		color = lipgloss.Color("203")
+	}
+
+	clock := formatClock(remaining)
+	digits := strings.Join(bigText(clock), "\n")
+	if lipgloss.Width(digits) > m.width-4 {
+		digits = clock
+	}
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(t.Name),
+		"",
+		lipgloss.NewStyle().Foreground(color).Render(digits),
+		"",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("245")).
+			Render(fmt.Sprintf("%s · %d/%d · %s/%s switch · %s pause · esc back", t.StatusText(m.now), m.cursor+1, len(m.getVisibleTimers()),
+				m.defaultKeys.Up.Help().Key, m.defaultKeys.Down.Help().Key, m.defaultKeys.Pause.Help().Key)),
+	}
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 }
 
 func setupTableStyles(tbl table.Model) table.Model {
@@ -124,7 +500,7 @@ func renderMainView(m model) string {
 	updateTableRows(&m)
 
 	// Build timer table
-	timerTable := m.table.View()
+	timerTable := colorCells(m.table.View(), m)
 
 	// Combine filter panel and table side by side
 	filterLines := strings.Split(filterPanel, "\n")
@@ -134,7 +510,8 @@ func renderMainView(m model) string {
 	maxFilterLines := len(filterLines)
 	for i := 0; i < maxFilterLines || i < len(timerLines); i++ {
 		if i < len(filterLines) {
-			fmt.Fprintf(&b, "%-20s", filterLines[i])
+			// Pad by display width; the search box contains styling escapes
+			b.WriteString(filterLines[i] + strings.Repeat(" ", max(0, 20-lipgloss.Width(filterLines[i]))))
 		} else {
 			b.WriteString(strings.Repeat(" ", 20))
 		}
@@ -146,10 +523,35 @@ func renderMainView(m model) string {
 		}
 	}
 
-	b.WriteString("\n" + m.help.View(m.defaultKeys))
+	if m.sequence {
+		b.WriteString("\n" + renderSequence(m))
+	}
+	b.WriteString("\n" + renderSummary(m))
+	b.WriteString("\n" + renderFooter(m))
 	return b.String()
 }
 
+// renderSummary counts timers by status, using the same rules as the filters
+func renderSummary(m model) string {
+	if m.flashMsg != "" && m.now.Before(m.flashUntil) {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render(" " + m.flashMsg)
+	}
+	var active, paused, done int
+	for _, t := range m.timers {
+		switch {
+		case t.Paused:
+			paused++
+		case t.End.After(m.now):
+			active++
+		default:
+			done++
+		}
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).
+		Render(fmt.Sprintf(" %d active · %d paused · %d done · %d total · %s left",
+			active, paused, done, len(m.timers), formatDuration(totalRemaining(m.timers, m.now))))
+}
+
 func renderPopupForm(m model) string {
 	// Define styles
 	var (
@@ -180,6 +582,12 @@ func renderPopupForm(m model) string {
 		hintStyle = lipgloss.NewStyle().
 			Foreground(hintColor)
 
+		validStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")) // Green
+
+		invalidStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")) // Red
+
 		helpStyle = lipgloss.NewStyle().
 			MarginTop(1).
 			Foreground(lipgloss.Color("245"))
@@ -216,18 +624,91 @@ func renderPopupForm(m model) string {
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, nameLabel, " ", m.nameInput.View()))
 	b.WriteString("\n\n")
 
-	// Duration input
+	// Duration input, or the end time in until mode
 	durationLabel := "Duration:"
+	if m.untilMode {
+		durationLabel = "Until:"
+	}
 	if m.durationInput.Focused() {
 		durationLabel = focusedLabelStyle.Render(durationLabel)
 	} else {
 		durationLabel = labelStyle.Render(durationLabel)
 	}
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, durationLabel, " ", m.durationInput.View()))
+	b.WriteString("\n")
+
+	// Live preview of what the duration field parses to; enter refuses while invalid
+	preview := strings.Repeat(" ", 10)
+	if d, err := m.formDuration(); err == nil {
+		preview += validStyle.Render("= " + formatDuration(d))
+	} else {
+		preview += invalidStyle.Render(ansi.Truncate(err.Error(), 44, "…"))
+	}
+	b.WriteString(preview)
+	b.WriteString("\n\n")
+
+	// Repeat input
+	repeatLabel := "Repeat:"
+	if m.repeatInput.Focused() {
+		repeatLabel = focusedLabelStyle.Render(repeatLabel)
+	} else {
+		repeatLabel = labelStyle.Render(repeatLabel)
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, repeatLabel, " ", m.repeatInput.View()))
 	b.WriteString("\n\n")
 
+	// Tags input
+	tagsLabel := "Tags:"
+	if m.tagsInput.Focused() {
+		tagsLabel = focusedLabelStyle.Render(tagsLabel)
+	} else {
+		tagsLabel = labelStyle.Render(tagsLabel)
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, tagsLabel, " ", m.tagsInput.View()))
+	b.WriteString("\n\n")
+
+	// Note input
+	noteLabel := "Note:"
+	if m.noteInput.Focused() {
+		noteLabel = focusedLabelStyle.Render(noteLabel)
+	} else {
+		noteLabel = labelStyle.Render(noteLabel)
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, noteLabel, " ", m.noteInput.View()))
+	b.WriteString("\n\n")
+
+	// Start paused toggle (new timers only)
+	if m.state == stateAdding {
+		check := "[ ]"
+		if m.startPaused {
+			check = "[x]"
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Start:"), " ", check+" paused (ctrl+p)"))
+		b.WriteString("\n\n")
+	}
+
+	// Keep progress toggle (edits only): adjust the end instead of restarting
+	if m.state == stateEditing {
+		check := "[ ]"
+		if m.keepProgress {
+			check = "[x]"
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Progress:"), " ", check+" keep, don't restart (ctrl+o)"))
+		b.WriteString("\n\n")
+	}
+
+	// Why enter was refused, until the next keystroke
+	if m.formError != "" {
+		b.WriteString(invalidStyle.Render(ansi.Truncate(m.formError, 54, "…")))
+		b.WriteString("\n\n")
+	}
+
 	// Validation hint
-	b.WriteString(hintStyle.Render("Examples: 30s, 5m, 1h | +/- to adjust"))
+	if m.untilMode {
+		b.WriteString(hintStyle.Render("Examples: 17:00, 2025-06-01T09:00 | ctrl+t: duration"))
+	} else {
+		b.WriteString(hintStyle.Render("Examples: 30s, 5m, 1h | +/- to adjust | ctrl+t: until"))
+	}
 	b.WriteString("\n")
 
 	// Help text
@@ -276,6 +757,14 @@ func renderConfirmPopup(m model) string {
 		actualIdx := m.getActualTimerIndex(m.cursor)
 		title = "🔄  Restart Timer"
 		message = fmt.Sprintf("Restart \"%s\"?", m.timers[actualIdx].Name)
+	} else if m.state == stateConfirmReset {
+		actualIdx := m.getActualTimerIndex(m.cursor)
+		title = "⏮️  Reset Timer"
+		message = fmt.Sprintf("Reset \"%s\" to %s, paused?", m.timers[actualIdx].Name, formatDuration(m.timers[actualIdx].Duration))
+	} else if m.state == stateConfirmLong {
+		title = "📅  Long Timer"
+		end := m.now.Add(m.pendingDuration)
+		message = fmt.Sprintf("This will end on %s (%s) — confirm?", formatEndTime(end, m.now, m.durationConfig.displayLocation(), m.durationConfig.endTimeLayouts(tuiEndTimeLayouts)), formatDuration(m.pendingDuration))
 	} else {
 		switch m.pendingBulkAction {
 		case bulkPauseAll:
@@ -283,7 +772,13 @@ func renderConfirmPopup(m model) string {
 			message = "Pause all active timers?"
 		case bulkResumeAll:
 			title = "▶️  Resume All Paused"
-			message = "Resume all paused timers?"
+			message = "Resume timers paused by Pause-All?"
+		case bulkPauseVisible:
+			title = "⏸️  Pause Visible"
+			message = fmt.Sprintf("Pause the active timers among the %d visible?", len(m.getVisibleTimers()))
+		case bulkResumeVisible:
+			title = "▶️  Resume Visible"
+			message = fmt.Sprintf("Resume bulk-paused timers among the %d visible?", len(m.getVisibleTimers()))
 		case bulkDeleteDone:
 			title = "🗑️  Delete Completed"
 			message = "Delete all completed timers?"
@@ -312,6 +807,97 @@ func renderConfirmPopup(m model) string {
 	return popupStyle.Render(b.String())
 }
 
+func renderInfoPopup(m model) string {
+	// Define styles
+	var (
+		borderColor = lipgloss.Color("99")  // Purple border
+		labelColor  = lipgloss.Color("147") // Light blue for labels
+		hintColor   = lipgloss.Color("244") // Gray for hints
+
+		popupStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(borderColor).
+				Padding(1, 2).
+				Width(58)
+
+		titleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("213")). // Pink/purple title
+				MarginBottom(1)
+
+		labelStyle = lipgloss.NewStyle().
+				Width(11).
+				Foreground(labelColor)
+
+		// Values wrap beside their label instead of under it
+		valueStyle = lipgloss.NewStyle().
+				Width(41)
+
+		hintStyle = lipgloss.NewStyle().
+				MarginTop(1).
+				Foreground(hintColor)
+
+		divider = lipgloss.NewStyle().
+			Foreground(hintColor).
+			Render(strings.Repeat("─", 54))
+	)
+
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render("ℹ️  Timer Details"))
+	b.WriteString("\n")
+	b.WriteString(divider)
+	b.WriteString("\n\n")
+
+	// The timer may disappear underneath the popup on an external reload
+	actualIdx := m.getActualTimerIndex(m.cursor)
+	if actualIdx < 0 {
+		b.WriteString(labelStyle.Render("No timer selected"))
+		b.WriteString("\n")
+	} else {
+		t := m.timers[actualIdx]
+		note := t.Note
+		if note == "" {
+			note = "-"
+		}
+		// Unlike the table, show the full end date and the remaining time to the second
+		end := t.End.In(m.durationConfig.displayLocation()).Format("Mon 2 Jan 2006 15:04:05 MST")
+		remaining := t.remainingAt(m.now)
+		if t.Paused {
+			end = "(paused)"
+			remaining = t.Remaining
+		}
+		started := "-"
+		if !t.Started.IsZero() {
+			started = t.Started.In(m.durationConfig.displayLocation()).Format("Mon 2 Jan 2006 15:04:05 MST")
+		}
+		remainingText := remaining.Round(time.Second).String()
+		if remaining <= 0 {
+			remainingText = "done " + formatAgo(t.doneFor(m.now))
+		}
+		fields := [][2]string{
+			{"Name:", t.Name},
+			{"Note:", note},
+			{"Duration:", formatDuration(t.Duration)},
+			{"Started:", started},
+			{"Elapsed:", t.ElapsedText(m.now)},
+			{"End:", end},
+			{"Remaining:", remainingText},
+			{"Status:", t.status(m.now)},
+		}
+		for _, f := range fields {
+			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(f[0]), valueStyle.Render(f[1])))
+			b.WriteString("\n")
+		}
+	}
+
+	// Help text
+	b.WriteString(hintStyle.Render("esc/enter/i: close"))
+
+	return popupStyle.Render(b.String())
+}
+
 func renderPopupOverlay(m model) string {
 	// Get dimensions
 	width := m.width
@@ -329,20 +915,30 @@ func renderPopupOverlay(m model) string {
 
 	// Render the popup
 	var popup string
-	if m.state == stateConfirmDelete || m.state == stateConfirmRestart || m.state == stateConfirmBulk {
+	if m.confirming() {
 		popup = renderConfirmPopup(m)
+	} else if m.state == stateInfo {
+		popup = renderInfoPopup(m)
 	} else {
 		popup = renderPopupForm(m)
 	}
 	popupLines := strings.Split(popup, "\n")
 	popupHeight := len(popupLines)
 
+	// Measure in terminal cells (emoji and ANSI styles make byte lengths useless)
+	// and clip the popup to terminals narrower than it
+	popupWidth := lipgloss.Width(popup)
+	if popupWidth > width {
+		popupWidth = width
+		for i, line := range popupLines {
+			popupLines[i] = ansi.Truncate(line, width, "")
+		}
+	}
+
 	// Calculate vertical position for popup (centered)
 	popupStartRow := max(0, (height-popupHeight)/2)
 
 	// Calculate horizontal position for popup (centered)
-	// Popup width is 58 (from style) + borders (4) + padding (4) = ~66 chars
-	popupWidth := 66
 	popupStartCol := max(0, (width-popupWidth)/2)
 	popupEndCol := popupStartCol + popupWidth
 
@@ -364,22 +960,22 @@ func renderPopupOverlay(m model) string {
 			// Build the line: background + popup
 			var lineBuilder strings.Builder
 
-			// Part before popup (normal background)
-			if len(bgLine) > popupStartCol {
-				lineBuilder.WriteString(bgLine[:popupStartCol])
-			} else {
-				lineBuilder.WriteString(bgLine)
-				if len(bgLine) < popupStartCol {
-					lineBuilder.WriteString(strings.Repeat(" ", popupStartCol-len(bgLine)))
-				}
+			// Part before popup (normal background), padded when short or when
+			// a wide character straddles the popup edge
+			left := ansi.Truncate(bgLine, popupStartCol, "")
+			lineBuilder.WriteString(left)
+			if !noColor {
+				lineBuilder.WriteString(ansi.ResetStyle)
 			}
+			lineBuilder.WriteString(strings.Repeat(" ", popupStartCol-lipgloss.Width(left)))
 
 			// The popup itself
 			lineBuilder.WriteString(popupLine)
 
 			// Part after popup (normal background, if needed)
-			if len(bgLine) > popupEndCol {
-				lineBuilder.WriteString(bgLine[popupEndCol:])
+			if lipgloss.Width(bgLine) > popupEndCol && popupEndCol < width {
+				right := ansi.TruncateLeft(bgLine, popupEndCol, "")
+				lineBuilder.WriteString(ansi.Truncate(right, width-popupEndCol, ""))
 			}
 
 			result.WriteString(lineBuilder.String())
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestUpdateTableRowsTruncatesWideNames(t *testing.T) {
+	useTempFiles(t)
+	m := newTestModel(t, []Timer{{
+		ID:       "a",
+		Name:     "日本語のとても長いタイマーの名前ですよ本当に長い",
+		Duration: time.Minute,
+		End:      testNow.Add(time.Minute),
+		Pinned:   true,
+	}})
+	updateTableRows(&m)
+
+	col := columnIndex(m.table.Columns(), "Name")
+	name := m.table.Rows()[0][col]
+	if !utf8.ValidString(name) {
+		t.Fatalf("truncated name %q is not valid UTF-8", name)
+	}
+	if w, limit := lipgloss.Width(name), m.table.Columns()[col].Width-2; w > limit {
+		t.Errorf("name %q is %d cells wide, want at most %d", name, w, limit)
+	}
+}
+
+func TestDensityToggleShowsNotesAndIsRemembered(t *testing.T) {
+	useTempFiles(t)
+	timers := []Timer{{ID: "a", Name: "Call", Duration: time.Hour, End: testNow.Add(time.Hour), Note: "dial in\nfrom the desk"}}
+	m := newTestModel(t, timers)
+	updateTableRows(&m)
+
+	col := columnIndex(m.table.Columns(), "Note")
+	if col < 0 {
+		t.Fatalf("detailed columns %v have no note", m.table.Columns())
+	}
+	if got := m.table.Rows()[0][col]; got != "dial in from the desk" {
+		t.Errorf("note cell = %q, want the note on one line", got)
+	}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = next.(model)
+	if !m.compact || columnIndex(m.table.Columns(), "Note") >= 0 {
+		t.Fatalf("compact = %v with columns %v, want compact rows without the note", m.compact, m.table.Columns())
+	}
+
+	// The next run starts in the density the last one ended in
+	if m := newTestModel(t, timers); !m.compact {
+		t.Error("compact rows not restored on the next start")
+	}
+}
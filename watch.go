@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// newSaveFileWatcher watches the directory holding the timers file, since
+// editors often replace the file rather than write to it. It returns nil when
+// watching is unavailable, in which case the TUI falls back to polling.
+func newSaveFileWatcher() *fsnotify.Watcher {
+	dir := filepath.Dir(saveFile)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	if err := w.Add(dir); err != nil {
+		w.Close()
+		return nil
+	}
+	return w
+}
+
+// waitForSaveFileChange delivers a fileWatchMsg when the timers file changes
+func waitForSaveFileChange(w *fsnotify.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return nil
+				}
+				if filepath.Clean(event.Name) == filepath.Clean(saveFile) &&
+					event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					return fileWatchMsg{}
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return nil
+				}
+			}
+		}
+	}
+}